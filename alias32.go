@@ -33,3 +33,21 @@ func NilInt() Int {
 func (v Int) Int() int {
 	return int(v.Int32())
 }
+
+// Uint represents a nil-able uint
+type Uint = Uint32
+
+// NewUint makes a new non-nil Uint
+func NewUint(v uint) Uint {
+	return NewUint32(uint32(v))
+}
+
+// NilUint makes a new nil Uint
+func NilUint() Uint {
+	return NilUint32()
+}
+
+// Uint returns the built-in uint value
+func (v Uint) Uint() uint {
+	return uint(v.Uint32())
+}
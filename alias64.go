@@ -33,3 +33,21 @@ func NilInt() Int {
 func (v Int) Int() int {
 	return int(v.Int64())
 }
+
+// Uint represents a nil-able uint
+type Uint = Uint64
+
+// NewUint makes a new non-nil Uint
+func NewUint(v uint) Uint {
+	return NewUint64(uint64(v))
+}
+
+// NilUint makes a new nil Uint
+func NilUint() Uint {
+	return NilUint64()
+}
+
+// Uint returns the built-in uint value
+func (v Uint) Uint() uint {
+	return uint(v.Uint64())
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// arrayOIDs maps the element types supported by Array to the OID of the
+// PostgreSQL array type they're encoded as
+var arrayOIDs = map[reflect.Type]uint32{
+	reflect.TypeOf(Bool{}):    pgtype.BoolArrayOID,
+	reflect.TypeOf(Int8{}):    pgtype.Int2ArrayOID,
+	reflect.TypeOf(Int16{}):   pgtype.Int2ArrayOID,
+	reflect.TypeOf(Int32{}):   pgtype.Int4ArrayOID,
+	reflect.TypeOf(Int64{}):   pgtype.Int8ArrayOID,
+	reflect.TypeOf(Uint8{}):   pgtype.Int2ArrayOID,
+	reflect.TypeOf(Uint16{}):  pgtype.Int4ArrayOID,
+	reflect.TypeOf(Uint32{}):  pgtype.Int8ArrayOID,
+	reflect.TypeOf(Uint64{}):  pgtype.NumericArrayOID,
+	reflect.TypeOf(Float{}):   pgtype.Float8ArrayOID,
+	reflect.TypeOf(Decimal{}): pgtype.NumericArrayOID,
+	reflect.TypeOf(String{}):  pgtype.TextArrayOID,
+	reflect.TypeOf(UUID{}):    pgtype.UUIDArrayOID,
+	reflect.TypeOf(Date{}):    pgtype.DateArrayOID,
+	reflect.TypeOf(Time{}):    pgtype.TimestamptzArrayOID,
+}
+
+// arrayMaps pools the pgtype.Maps that Arrays are encoded and decoded with
+// through database/sql, since a Map is expensive to build but not safe for
+// concurrent use
+var arrayMaps = sync.Pool{
+	New: func() any {
+		return pgtype.NewMap()
+	},
+}
+
+// Array represents a nil-able one-dimensional PostgreSQL array whose elements
+// are nil-able. A nil Array is a NULL array, while an empty non-nil Array is
+// an empty one.
+//
+// T may be any of Bool, Int8, Int16, Int32, Int64, Uint8, Uint16, Uint32,
+// Uint64, Float, Decimal, String, UUID, Date or Time. Arrays are scanned and
+// valued through pgx in both the text and binary formats, and through
+// database/sql in the text format.
+type Array[T any] []T
+
+// Int64Array represents a nil-able int8[]
+type Int64Array = Array[Int64]
+
+// StringArray represents a nil-able text[]
+type StringArray = Array[String]
+
+// UUIDArray represents a nil-able uuid[]
+type UUIDArray = Array[UUID]
+
+// DateArray represents a nil-able date[]
+type DateArray = Array[Date]
+
+// Nil returns whether this array is nil
+func (a Array[T]) Nil() bool {
+	return a == nil
+}
+
+// Value implements the driver.Valuer interface, encoding the array in the
+// PostgreSQL text format
+func (a Array[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	oid, err := a.oid()
+	if err != nil {
+		return nil, err
+	}
+	m := arrayMaps.Get().(*pgtype.Map)
+	defer arrayMaps.Put(m)
+	buf, err := m.Encode(oid, pgtype.TextFormatCode, a, []byte{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(buf), nil
+}
+
+// Scan implements the sql.Scanner interface, decoding the PostgreSQL text
+// format
+func (a *Array[T]) Scan(src any) error {
+	var buf []byte
+	switch t := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		buf = []byte(t)
+	case []byte:
+		buf = t
+	default:
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to an array", src)
+	}
+	oid, err := a.oid()
+	if err != nil {
+		return err
+	}
+	m := arrayMaps.Get().(*pgtype.Map)
+	defer arrayMaps.Put(m)
+	if err := m.Scan(oid, pgtype.TextFormatCode, buf, a); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (a Array[T]) oid() (uint32, error) {
+	var t T
+	oid, ok := arrayOIDs[reflect.TypeOf(t)]
+	if !ok {
+		return 0, errors.Errorf("unsupported array element type %T", t)
+	}
+	return oid, nil
+}
+
+// Dimensions implements the pgtype.ArrayGetter interface
+func (a Array[T]) Dimensions() []pgtype.ArrayDimension {
+	if a == nil {
+		return nil
+	}
+	if len(a) == 0 {
+		return []pgtype.ArrayDimension{}
+	}
+	return []pgtype.ArrayDimension{{Length: int32(len(a)), LowerBound: 1}}
+}
+
+// Index implements the pgtype.ArrayGetter interface
+func (a Array[T]) Index(i int) any {
+	return a[i]
+}
+
+// IndexType implements the pgtype.ArrayGetter interface
+func (a Array[T]) IndexType() any {
+	var t T
+	return t
+}
+
+// SetDimensions implements the pgtype.ArraySetter interface. Multi-dimensional
+// arrays are flattened.
+func (a *Array[T]) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	if dimensions == nil {
+		*a = nil
+		return nil
+	}
+	n := 0
+	if len(dimensions) > 0 {
+		n = 1
+		for _, d := range dimensions {
+			n *= int(d.Length)
+		}
+	}
+	*a = make(Array[T], n)
+	return nil
+}
+
+// ScanIndex implements the pgtype.ArraySetter interface
+func (a Array[T]) ScanIndex(i int) any {
+	return &a[i]
+}
+
+// ScanIndexType implements the pgtype.ArraySetter interface
+func (a Array[T]) ScanIndexType() any {
+	return new(T)
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArray_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		oid  uint32
+		give any
+	}{
+		{"Nil Int64Array", pgtype.Int8ArrayOID, Int64Array(nil)},
+		{"Empty Int64Array", pgtype.Int8ArrayOID, Int64Array{}},
+		{"Int64Array", pgtype.Int8ArrayOID, Int64Array{NewInt64(1), NilInt64(), NewInt64(-3)}},
+		{"StringArray", pgtype.TextArrayOID, StringArray{NewString("a, \"b\""), NilString(), NewString(""), NewString("NULL")}},
+		{"UUIDArray", pgtype.UUIDArrayOID, UUIDArray{NewUUID(stubUUID), NilUUID()}},
+		{"DateArray", pgtype.DateArrayOID, DateArray{NewDate("2019-11-12"), NilDate(), NewInfiniteDate()}},
+		{"Int32 Array", pgtype.Int4ArrayOID, Array[Int32]{NewInt32(1), NilInt32()}},
+		{"Bool Array", pgtype.BoolArrayOID, Array[Bool]{NewBool(true), NilBool()}},
+		{"Float Array", pgtype.Float8ArrayOID, Array[Float]{NewFloat(1.5), NilFloat()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPgRoundTrip(t, tt.oid, tt.give)
+		})
+	}
+}
+
+func TestArray_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give driver.Valuer
+		want driver.Value
+	}{
+		{"Nil", Int64Array(nil), nil},
+		{"Empty", Int64Array{}, "{}"},
+		{"Int64Array", Int64Array{NewInt64(1), NilInt64(), NewInt64(3)}, "{1,NULL,3}"},
+		{"StringArray", StringArray{NewString("a b"), NilString(), NewString("NULL"), NewString("")}, `{a b,NULL,"NULL",""}`},
+		{"UUIDArray", UUIDArray{NewUUID(stubUUID), NilUUID()}, "{" + stubUUIDString + ",NULL}"},
+		{"DateArray", DateArray{NewDate("2019-11-12"), NilDate(), NewNegInfiniteDate()}, "{2019-11-12,NULL,-infinity}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestArray_Scan(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		got := Int64Array{NewInt64(1)}
+		assert.NoError(t, got.Scan(nil))
+		assert.Nil(t, got)
+		assert.True(t, got.Nil())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		var got Int64Array
+		assert.NoError(t, got.Scan("{}"))
+		assert.Equal(t, Int64Array{}, got)
+		assert.False(t, got.Nil())
+	})
+	t.Run("Int64Array", func(t *testing.T) {
+		var got Int64Array
+		assert.NoError(t, got.Scan([]byte("{1,NULL,3}")))
+		assert.Equal(t, Int64Array{NewInt64(1), NilInt64(), NewInt64(3)}, got)
+	})
+	t.Run("StringArray", func(t *testing.T) {
+		var got StringArray
+		assert.NoError(t, got.Scan(`{"a b",NULL,"NULL",""}`))
+		assert.Equal(t, StringArray{NewString("a b"), NilString(), NewString("NULL"), NewString("")}, got)
+	})
+	t.Run("UUIDArray", func(t *testing.T) {
+		var got UUIDArray
+		assert.NoError(t, got.Scan("{"+stubUUIDString+",NULL}"))
+		assert.Equal(t, UUIDArray{NewUUID(stubUUID), NilUUID()}, got)
+	})
+	t.Run("DateArray", func(t *testing.T) {
+		var got DateArray
+		assert.NoError(t, got.Scan("{2019-11-12,NULL,infinity}"))
+		assert.Equal(t, DateArray{NewDate("2019-11-12"), NilDate(), NewInfiniteDate()}, got)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var got Int64Array
+		assert.Error(t, got.Scan("{a}"))
+		assert.Error(t, got.Scan(int64(1)))
+	})
+	t.Run("Unsupported Element", func(t *testing.T) {
+		var got Array[int]
+		assert.Error(t, got.Scan("{1}"))
+	})
+}
+
+func TestArray_Concurrent(t *testing.T) {
+	give := StringArray{NewString("a"), NilString()}
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for j := 0; j < 100; j++ {
+				v, err := give.Value()
+				assert.NoError(t, err)
+				var got StringArray
+				assert.NoError(t, got.Scan(v))
+				assert.Equal(t, give, got)
+			}
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
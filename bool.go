@@ -15,29 +15,24 @@
 package nillabletypes
 
 import (
-	"bytes"
 	"database/sql/driver"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
-	"github.com/segmentio/encoding/json"
 )
 
 // Bool represents a nil-able bool
-type Bool struct {
-	v           bool
-	present     bool
-	initialized bool
-}
+type Bool Nillable[bool]
 
 // NewBool makes a new non-nil Bool
 func NewBool(v bool) Bool {
-	return Bool{v: v, present: true, initialized: true}
+	return Bool(New(v))
 }
 
 // NilBool makes a new nil Bool
 func NilBool() Bool {
-	return Bool{v: false, present: false, initialized: true}
+	return Bool(Nil[bool]())
 }
 
 // Bool returns the built-in bool value
@@ -47,7 +42,28 @@ func (v Bool) Bool() bool {
 
 // Nil returns whether this scalar is nil
 func (v Bool) Nil() bool {
-	return !v.present
+	return Nillable[bool](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Bool) Initialized() bool {
+	return Nillable[bool](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Bool) Set() bool {
+	return Nillable[bool](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Bool) State() State {
+	return Nillable[bool](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Bool) Reset() {
+	(*Nillable[bool])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -57,38 +73,37 @@ func (v Bool) String() string {
 
 // UnmarshalJSON implements the json.Unmarshaler interface
 func (v *Bool) UnmarshalJSON(data []byte) error {
-	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
-		v.present = false
-		v.initialized = true
-		return nil
-	}
-	err := json.Unmarshal(data, &v.v)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	v.present = true
-	v.initialized = true
-	return nil
+	return (*Nillable[bool])(v).UnmarshalJSON(data)
 }
 
 // MarshalJSON implements the json.Marshaler interface
 func (v Bool) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
-	}
-	return json.Marshal(v.v)
+	return Nillable[bool](v).MarshalJSON()
 }
 
-// Value implements the driver.Valuer interface
+// Value implements the driver.Valuer interface. Under the MySQL and SQLite
+// dialects the value is 0 or 1.
 func (v Bool) Value() (driver.Value, error) {
-	if !v.present {
-		return nil, nil
+	return v.value(CurrentDialect())
+}
+
+func (v Bool) value(d Dialect) (driver.Value, error) {
+	if v.present && (d == MySQL || d == SQLite) {
+		if v.v {
+			return int64(1), nil
+		}
+		return int64(0), nil
 	}
-	return v.v, nil
+	return Nillable[bool](v).Value()
 }
 
-// Scan implements the sql.Scanner interface
+// Scan implements the sql.Scanner interface. Under the MySQL and SQLite
+// dialects it also accepts text such as "1" or "true".
 func (v *Bool) Scan(src interface{}) error {
+	return v.scan(CurrentDialect(), src)
+}
+
+func (v *Bool) scan(d Dialect, src any) error {
 	if src == nil {
 		*v = Bool{present: false, initialized: true}
 		return nil
@@ -111,6 +126,34 @@ func (v *Bool) Scan(src interface{}) error {
 			*v = Bool{v: true, present: true, initialized: true}
 		}
 		return nil
+	case []byte:
+		if d == MySQL || d == SQLite {
+			return v.scanString(string(t))
+		}
+	case string:
+		if d == MySQL || d == SQLite {
+			return v.scanString(t)
+		}
 	}
 	return errors.Errorf("cannot scan value %[1]v of type %[1]T to a bool", src)
 }
+
+func (v *Bool) scanString(src string) error {
+	b, err := strconv.ParseBool(src)
+	if err != nil {
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to a bool", src)
+	}
+	*v = Bool{v: b, present: true, initialized: true}
+	return nil
+}
+
+// ScanBool implements the pgtype.BoolScanner interface
+func (v *Bool) ScanBool(src pgtype.Bool) error { //nolint:unparam
+	*v = Bool{v: src.Bool, present: src.Valid, initialized: true}
+	return nil
+}
+
+// BoolValue implements the pgtype.BoolValuer interface
+func (v Bool) BoolValue() (pgtype.Bool, error) { //nolint:unparam
+	return pgtype.Bool{Bool: v.v, Valid: v.present}, nil
+}
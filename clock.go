@@ -0,0 +1,71 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the time-relative helpers of this package, such as
+// Date.DaysAgo, what the current time is. Days are counted in the location of
+// the times it returns, so a Clock returning times in America/Los_Angeles
+// counts Pacific days even on a host whose local time zone is UTC.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a Clock, e.g.
+//
+//	pacific, _ := time.LoadLocation("America/Los_Angeles")
+//	nillabletypes.SetClock(nillabletypes.ClockFunc(func() time.Time {
+//		return time.Now().In(pacific)
+//	}))
+type ClockFunc func() time.Time
+
+// Now implements the Clock interface
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the default Clock, which returns time.Now() in the local
+// time zone
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var defaultClock atomic.Pointer[Clock]
+
+// SetClock sets the Clock used by the time-relative helpers of this package.
+// Setting a nil Clock restores SystemClock.
+func SetClock(c Clock) {
+	if c == nil {
+		defaultClock.Store(nil)
+		return
+	}
+	defaultClock.Store(&c)
+}
+
+// CurrentClock returns the Clock set with SetClock, or SystemClock
+func CurrentClock() Clock {
+	if c := defaultClock.Load(); c != nil {
+		return *c
+	}
+	return SystemClock
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetClock(t *testing.T) {
+	defer SetClock(nil)
+
+	assert.Equal(t, SystemClock, CurrentClock())
+
+	now := time.Date(2019, 11, 12, 10, 0, 0, 0, time.UTC)
+	SetClock(ClockFunc(func() time.Time { return now }))
+	assert.Equal(t, now, CurrentClock().Now())
+	assert.Equal(t, NewDate("2019-11-12"), Today())
+
+	got, err := NewDate("2019-11-02").DaysAgo()
+	assert.NoError(t, err)
+	assert.Equal(t, NewInt64(10), got)
+
+	got, err = NewDate("2000-11-13").Age()
+	assert.NoError(t, err)
+	assert.Equal(t, NewInt64(18), got)
+
+	SetClock(nil)
+	assert.Equal(t, SystemClock, CurrentClock())
+}
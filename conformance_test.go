@@ -0,0 +1,121 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoDriver is a database/sql driver that records the arguments of every
+// statement and returns them as the single row of the statement's result
+type echoDriver struct{}
+
+type echoConn struct{}
+
+type echoStmt struct{}
+
+type echoRows struct {
+	args []driver.Value
+	done bool
+}
+
+func init() {
+	sql.Register("nillabletypes-echo", echoDriver{})
+}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return -1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{args: args}, nil
+}
+
+func (r *echoRows) Columns() []string { return make([]string, len(r.args)) }
+func (r *echoRows) Close() error      { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.args)
+	return nil
+}
+
+// conformanceValues are values of every type in the package, both nil and not
+var conformanceValues = []any{
+	NewBool(true), NilBool(),
+	NewInt32(math.MinInt32), NilInt32(),
+	NewInt64(math.MaxInt64), NilInt64(),
+	NewUint32(math.MaxUint32), NilUint32(),
+	NewInt8(math.MinInt8), NilInt8(),
+	NewInt16(math.MinInt16), NilInt16(),
+	NewUint8(math.MaxUint8), NilUint8(),
+	NewUint16(math.MaxUint16), NilUint16(),
+	NewUint64(math.MaxInt64), NewUint64(math.MaxUint64), NilUint64(),
+	NewFloat(3.14159), NilFloat(),
+	NewDecimal(-12345, 2), MustParseDecimal("0.000"), NilDecimal(),
+	MustParseMoney("-123.45", "USD"), MustParseMoney("1000", "JPY"), NilMoney(),
+	NewString("hello"), NilString(),
+	NewUUID(stubUUID), NilUUID(),
+	NewDate("2019-11-12"), NewInfiniteDate(), NilDate(),
+	NewTime(time.Date(2019, 11, 12, 10, 0, 0, 0, time.UTC)), NewInfiniteTime(), NilTime(),
+	Int64Array{NewInt64(1), NilInt64()}, Int64Array(nil),
+	StringArray{NewString("a"), NilString()},
+	New(stubStatus("active")), Nil[stubStatus](),
+}
+
+func TestConformance_IsValue(t *testing.T) {
+	for _, give := range conformanceValues {
+		v, err := give.(driver.Valuer).Value()
+		assert.NoError(t, err, "%#v", give)
+		assert.True(t, driver.IsValue(v), "%T.Value() returned %T", give, v)
+	}
+}
+
+func TestConformance_Driver(t *testing.T) {
+	db, err := sql.Open("nillabletypes-echo", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+
+	for _, give := range conformanceValues {
+		_, err := db.Exec("", give)
+		assert.NoError(t, err, "%#v", give)
+
+		got := reflect.New(reflect.TypeOf(give))
+		err = db.QueryRow("", give).Scan(got.Interface())
+		if assert.NoError(t, err, "%#v", give) {
+			assert.Equal(t, give, got.Elem().Interface())
+		}
+	}
+}
@@ -16,68 +16,353 @@ package nillabletypes
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
-	"regexp"
+	"fmt"
+	"slices"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/segmentio/encoding/json"
 )
 
-// TODO(faulkner): technically this is incorrect since it'll match "2000-12-3456789"; useful if we want to trim the time from a datetime, but if we don't have that usecase then perhaps this should be more strict.
-var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+const (
+	dateInfinity    = "infinity"
+	dateNegInfinity = "-infinity"
+)
+
+// civilDate is a calendar date with no time of day or location, or one of
+// PostgreSQL's infinities
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+	inf   pgtype.InfinityModifier
+}
+
+// civilDateOf returns the date of t in t's location
+func civilDateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{year: y, month: m, day: d}
+}
+
+// time returns midnight at the start of this date in loc
+func (d civilDate) time(loc *time.Location) time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, loc)
+}
+
+// String formats this date as ISO 8601, or as 'infinity' or '-infinity'
+func (d civilDate) String() string {
+	switch d.inf {
+	case pgtype.Infinity:
+		return dateInfinity
+	case pgtype.NegativeInfinity:
+		return dateNegInfinity
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day)
+}
+
+// addMonths returns this date n months later, clamped to the end of the
+// month, e.g. January 31st plus one month is February 28th or 29th
+func (d civilDate) addMonths(n int) civilDate {
+	m := int(d.month) - 1 + n
+	y := d.year + m/12
+	if m %= 12; m < 0 {
+		m += 12
+		y--
+	}
+	r := civilDate{year: y, month: time.Month(m + 1), day: d.day}
+	r.day = min(r.day, r.daysInMonth())
+	return r
+}
+
+// daysInMonth returns the number of days in this date's month
+func (d civilDate) daysInMonth() int {
+	return civilDate{year: d.year, month: d.month + 1}.time(time.UTC).Day()
+}
 
-// Date represents a nil-able date encoded as ISO
-type Date struct {
-	v           string
-	present     bool
-	initialized bool
+// unixDays returns the number of days between the Unix epoch and this date
+func (d civilDate) unixDays() int64 {
+	return d.time(time.UTC).Unix() / (24 * 60 * 60)
 }
 
-// NewDate makes a new non-nil Date
+// compare returns -1, 0 or +1 as this date is before, equal to or after y,
+// ordering '-infinity' first and 'infinity' last
+func (d civilDate) compare(y civilDate) int {
+	if d.inf != y.inf {
+		return cmp.Compare(dateInfRank(d.inf), dateInfRank(y.inf))
+	}
+	if d.inf != pgtype.Finite {
+		return 0
+	}
+	return cmp.Compare(d.unixDays(), y.unixDays())
+}
+
+func dateInfRank(inf pgtype.InfinityModifier) int {
+	switch inf {
+	case pgtype.NegativeInfinity:
+		return -1
+	case pgtype.Infinity:
+		return 1
+	}
+	return 0
+}
+
+// Date represents a nil-able date, encoded as ISO 8601
+type Date Nillable[civilDate]
+
+// NewDate makes a new non-nil Date from an ISO 8601 date such as
+// "2019-11-12", or from "infinity" or "-infinity". It ignores the layouts set
+// with SetDateLayouts, so that Date literals don't depend on them, and panics
+// if v is not a valid ISO 8601 date; use ParseDate to check it or to accept
+// other layouts instead.
 func NewDate(v string) Date {
-	return Date{v: v, present: true, initialized: true}
+	d, err := isoDateLayouts.Parse(v)
+	if err != nil {
+		panic(errors.Wrap(err, "NewDate only accepts ISO 8601 dates, use ParseDate for other layouts"))
+	}
+	return d
+}
+
+// ParseDate makes a new non-nil Date from v in any of the layouts set with
+// SetDateLayouts, by default an ISO 8601 date such as "2019-11-12", or from
+// "infinity" or "-infinity". Dates that don't exist, such as "2019-02-30",
+// are rejected.
+func ParseDate(v string) (Date, error) {
+	return currentDateLayouts().Parse(v)
 }
 
 // NilDate makes a new nil Date
 func NilDate() Date {
-	return Date{v: "", present: false, initialized: true}
+	return Date(Nil[civilDate]())
+}
+
+// NewInfiniteDate makes a new Date later than all other dates, matching
+// PostgreSQL's 'infinity'
+func NewInfiniteDate() Date {
+	return Date(New(civilDate{inf: pgtype.Infinity}))
+}
+
+// NewNegInfiniteDate makes a new Date earlier than all other dates, matching
+// PostgreSQL's '-infinity'
+func NewNegInfiniteDate() Date {
+	return Date(New(civilDate{inf: pgtype.NegativeInfinity}))
 }
 
 // Nil returns whether this scalar is nil
 func (v Date) Nil() bool {
-	return !v.present
+	return Nillable[civilDate](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Date) Initialized() bool {
+	return Nillable[civilDate](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Date) Set() bool {
+	return Nillable[civilDate](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Date) State() State {
+	return Nillable[civilDate](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Date) Reset() {
+	(*Nillable[civilDate])(v).Reset()
+}
+
+// IsInfinite returns whether this Date is 'infinity'
+func (v Date) IsInfinite() bool {
+	return v.present && v.v.inf == pgtype.Infinity
+}
+
+// IsNegInfinite returns whether this Date is '-infinity'
+func (v Date) IsNegInfinite() bool {
+	return v.present && v.v.inf == pgtype.NegativeInfinity
 }
 
 // NewDateFromTime makes new Date from Time and matches its nihilism
 func NewDateFromTime(t Time) Date {
-	if t.Nil() {
+	switch {
+	case t.Nil():
 		return NilDate()
+	case t.IsInfinite():
+		return NewInfiniteDate()
+	case t.IsNegInfinite():
+		return NewNegInfiniteDate()
 	}
-	return NewDate(t.Time().Format(time.DateOnly))
+	return Date(New(civilDateOf(t.Time())))
 }
 
-// DaysAgo returns the number of days elapsed since Date
+// Today returns the current date according to the Clock set with SetClock
+func Today() Date {
+	return Date(New(civilDateOf(CurrentClock().Now())))
+}
+
+// DaysAgo returns the number of days elapsed since Date, according to the
+// Clock set with SetClock. It returns an empty Int64 if this Date is nil, and
+// an error if it is infinite.
 func (v Date) DaysAgo() (Int64, error) {
-	if v.Nil() {
+	return v.DaysAgoAt(CurrentClock().Now(), nil)
+}
+
+// DaysAgoAt returns the number of calendar days between Date and the date of
+// now in loc, or in now's own location if loc is nil. Days are counted by
+// date rather than by elapsed hours, so days shortened or lengthened by a
+// daylight saving time transition still count as one.
+func (v Date) DaysAgoAt(now time.Time, loc *time.Location) (Int64, error) {
+	if loc != nil {
+		now = now.In(loc)
+	}
+	return v.DaysBetween(Date(New(civilDateOf(now))))
+}
+
+// finite reports whether this Date is neither nil nor infinite
+func (v Date) finite() bool {
+	return v.present && v.v.inf == pgtype.Finite
+}
+
+// AddDays returns this Date n days later, or n days earlier if n is negative.
+// Nil and infinite Dates are returned unchanged.
+func (v Date) AddDays(n int) Date {
+	if !v.finite() {
+		return v
+	}
+	return Date(New(civilDateOf(v.v.time(time.UTC).AddDate(0, 0, n))))
+}
+
+// AddMonths returns this Date n months later, or n months earlier if n is
+// negative. Days past the end of the resulting month are clamped to it, so
+// 2019-01-31 plus one month is 2019-02-28. Nil and infinite Dates are
+// returned unchanged.
+func (v Date) AddMonths(n int) Date {
+	if !v.finite() {
+		return v
+	}
+	return Date(New(v.v.addMonths(n)))
+}
+
+// AddYears returns this Date n years later, or n years earlier if n is
+// negative. February 29th is clamped to February 28th in common years. Nil and
+// infinite Dates are returned unchanged.
+func (v Date) AddYears(n int) Date {
+	return v.AddMonths(12 * n)
+}
+
+// StartOfMonth returns the first day of this Date's month. Nil and infinite
+// Dates are returned unchanged.
+func (v Date) StartOfMonth() Date {
+	if !v.finite() {
+		return v
+	}
+	return Date(New(civilDate{year: v.v.year, month: v.v.month, day: 1}))
+}
+
+// EndOfMonth returns the last day of this Date's month. Nil and infinite Dates
+// are returned unchanged.
+func (v Date) EndOfMonth() Date {
+	if !v.finite() {
+		return v
+	}
+	return Date(New(civilDate{year: v.v.year, month: v.v.month, day: v.v.daysInMonth()}))
+}
+
+// DaysBetween returns the number of days from this Date to y, which is
+// negative if y is earlier. It returns an empty Int64 if either Date is nil,
+// and an error if either is infinite.
+func (v Date) DaysBetween(y Date) (Int64, error) {
+	if v.Nil() || y.Nil() {
 		return Int64{}, nil
 	}
+	if v.v.inf != pgtype.Finite || y.v.inf != pgtype.Finite {
+		return Int64{}, errors.Errorf("cannot count the days between %v and %v", v.v, y.v)
+	}
+	return NewInt64(y.v.unixDays() - v.v.unixDays()), nil
+}
 
-	t, err := time.ParseInLocation("2006-01-02", v.String(), time.Local)
-	if err != nil {
-		return Int64{}, err
+// YearsBetween returns the number of whole years from this Date to y, such as
+// an age from a birth date, which is negative if y is earlier. It returns an
+// empty Int64 if either Date is nil, and an error if either is infinite.
+func (v Date) YearsBetween(y Date) (Int64, error) {
+	if v.Nil() || y.Nil() {
+		return Int64{}, nil
+	}
+	if v.v.inf != pgtype.Finite || y.v.inf != pgtype.Finite {
+		return Int64{}, errors.Errorf("cannot count the years between %v and %v", v.v, y.v)
+	}
+	months := (y.v.year-v.v.year)*12 + int(y.v.month-v.v.month)
+	switch {
+	case months > 0 && y.v.day < v.v.day:
+		months--
+	case months < 0 && y.v.day > v.v.day:
+		months++
 	}
+	return NewInt64(int64(months / 12)), nil
+}
+
+// Age returns the number of whole years elapsed since this Date, such as the
+// age of a listing or a person, according to the Clock set with SetClock. It
+// returns an empty Int64 if this Date is nil, and an error if it is infinite.
+func (v Date) Age() (Int64, error) {
+	return v.YearsBetween(Today())
+}
+
+// Before reports whether this Date is earlier than y. It reports false if
+// either Date is nil.
+func (v Date) Before(y Date) bool {
+	return v.present && y.present && v.v.compare(y.v) < 0
+}
+
+// After reports whether this Date is later than y. It reports false if either
+// Date is nil.
+func (v Date) After(y Date) bool {
+	return v.present && y.present && v.v.compare(y.v) > 0
+}
 
-	return NewInt(int(time.Since(t).Hours() / 24)), nil
+// Equal reports whether this Date is the same date as y. It reports false if
+// either Date is nil.
+func (v Date) Equal(y Date) bool {
+	return v.present && y.present && v.v.compare(y.v) == 0
+}
+
+// Weekday returns the day of the week of this Date, or an empty Nillable if
+// this Date is nil or infinite
+func (v Date) Weekday() Nillable[time.Weekday] {
+	if !v.finite() {
+		return Nillable[time.Weekday]{}
+	}
+	return New(v.v.time(time.UTC).Weekday())
+}
+
+// Quarter returns the quarter of the year of this Date, from 1 to 4, or an
+// empty Int64 if this Date is nil or infinite
+func (v Date) Quarter() Int64 {
+	if !v.finite() {
+		return Int64{}
+	}
+	return NewInt64(int64(v.v.month-1)/3 + 1)
 }
 
 // String implements the fmt.Stringer interface
 func (v Date) String() string {
-	return v.v
+	if !v.present {
+		return ""
+	}
+	return v.v.String()
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts dates
+// in any of the layouts set with SetDateLayouts.
 func (v *Date) UnmarshalJSON(data []byte) error {
+	return v.unmarshalJSON(currentDateLayouts(), data)
+}
+
+func (v *Date) unmarshalJSON(l DateLayouts, data []byte) error {
 	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
 		*v = Date{present: false, initialized: true}
 		return nil
@@ -89,11 +374,11 @@ func (v *Date) UnmarshalJSON(data []byte) error {
 		return errors.WithStack(err)
 	}
 
-	if f := datePattern.FindString(s); f == "" {
-		return errors.Errorf("value %v is not a valid date", s)
+	d, err := l.Parse(s)
+	if err != nil {
+		return err
 	}
-
-	*v = Date{v: s, present: true, initialized: true}
+	*v = d
 	return nil
 }
 
@@ -102,26 +387,92 @@ func (v Date) MarshalJSON() ([]byte, error) {
 	if !v.initialized || !v.present {
 		return []byte{'n', 'u', 'l', 'l'}, nil
 	}
-	return json.Marshal(v.v)
+	return json.Marshal(v.v.String())
 }
 
 // Value implements the driver.Valuer interface
 func (v Date) Value() (driver.Value, error) {
+	return v.value(CurrentDialect())
+}
+
+func (v Date) value(d Dialect) (driver.Value, error) {
+	if d != Postgres && (v.IsInfinite() || v.IsNegInfinite()) {
+		return nil, errors.Errorf("%v has no representation for date %v", d, v.v)
+	}
 	if !v.present {
 		return nil, nil
 	}
-	return v.v, nil
+	return v.v.String(), nil
 }
 
-// Scan implements the sql.Scanner interface
+// Scan implements the sql.Scanner interface. It also accepts text, such as
+// that of a ::text cast or of a SQLite DATE column, in any of the layouts set
+// with SetDateLayouts, and timestamp text in the formats Time.Scan accepts,
+// whose date is taken. Text that can't be parsed returns a *ParseError.
 func (v *Date) Scan(src interface{}) error {
+	return v.scan(CurrentDialect(), src)
+}
+
+func (v *Date) scan(_ Dialect, src any) error {
+	return v.scanLayouts(currentDateLayouts(), src)
+}
+
+func (v *Date) scanLayouts(l DateLayouts, src any) error {
 	if src == nil {
 		*v = Date{present: false, initialized: true}
 		return nil
 	}
-	if t, ok := src.(time.Time); ok {
-		*v = Date{v: t.Format("2006-01-02"), present: true, initialized: true}
+	switch t := src.(type) {
+	case time.Time:
+		*v = Date{v: civilDateOf(t), present: true, initialized: true}
+		return nil
+	case []byte:
+		return v.scanString(l, string(t))
+	case string:
+		return v.scanString(l, t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to a date", src)
+}
+
+func (v *Date) scanString(l DateLayouts, s string) error {
+	l = slices.Clip(l)
+	for _, layout := range timeTextLayouts {
+		if !slices.Contains(l, layout) {
+			l = append(l, layout)
+		}
+	}
+	date, err := l.Parse(s)
+	if err != nil {
+		return &ParseError{Type: "date", Input: s, Err: errors.Errorf("not in any of the layouts %s", l.quoted())}
+	}
+	*v = date
+	return nil
+}
+
+// ScanDate implements the pgtype.DateScanner interface
+func (v *Date) ScanDate(src pgtype.Date) error { //nolint:unparam
+	if !src.Valid {
+		*v = Date{present: false, initialized: true}
 		return nil
 	}
-	return errors.Errorf("cannot scan value %v to a date", src)
+	switch src.InfinityModifier {
+	case pgtype.Infinity:
+		*v = NewInfiniteDate()
+	case pgtype.NegativeInfinity:
+		*v = NewNegInfiniteDate()
+	default:
+		*v = Date{v: civilDateOf(src.Time), present: true, initialized: true}
+	}
+	return nil
+}
+
+// DateValue implements the pgtype.DateValuer interface
+func (v Date) DateValue() (pgtype.Date, error) { //nolint:unparam
+	switch {
+	case !v.present:
+		return pgtype.Date{}, nil
+	case v.v.inf != pgtype.Finite:
+		return pgtype.Date{InfinityModifier: v.v.inf, Valid: true}, nil
+	}
+	return pgtype.Date{Time: v.v.time(time.UTC), Valid: true}, nil
 }
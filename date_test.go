@@ -16,32 +16,56 @@ package nillabletypes
 
 import (
 	"database/sql/driver"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func TestNewDate(t *testing.T) {
+	if got, want := NewDate("2019-11-12"), (Date{v: civilDate{year: 2019, month: time.November, day: 12}, present: true, initialized: true}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDate() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewDate() did not panic on an invalid date")
+		}
+	}()
+	NewDate("2019/11/12")
+}
+
+func TestParseDate(t *testing.T) {
 	tests := []struct {
-		name string
-		give string
-		want Date
+		name    string
+		give    string
+		want    Date
+		wantErr bool
 	}{
-		{
-			name: "Valid Format",
-			give: "2019-11-12",
-			want: Date{v: "2019-11-12", present: true, initialized: true},
-		},
-		{
-			name: "Invalid Format",
-			give: "2019/11/12",
-			want: Date{v: "2019/11/12", present: true, initialized: true},
-		},
+		{"Valid Format", "2019-11-12", Date{v: civilDate{year: 2019, month: time.November, day: 12}, present: true, initialized: true}, false},
+		{"Leap Day", "2020-02-29", Date{v: civilDate{year: 2020, month: time.February, day: 29}, present: true, initialized: true}, false},
+		{"Infinity", "infinity", NewInfiniteDate(), false},
+		{"Negative Infinity", "-infinity", NewNegInfiniteDate(), false},
+		{"Invalid Format", "2019/11/12", Date{}, true},
+		{"Trailing Digits", "2000-12-3456789", Date{}, true},
+		{"Trailing Time", "2019-11-12T10:30:00Z", Date{}, true},
+		{"Single Digit Month", "2019-1-12", Date{}, true},
+		{"February 30th", "2019-02-30", Date{}, true},
+		{"Not A Leap Year", "2019-02-29", Date{}, true},
+		{"13th Month", "2019-13-01", Date{}, true},
+		{"Empty", "", Date{}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewDate(tt.give); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("NewDate() = %v, want %v", got, tt.want)
+			got, err := ParseDate(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDate() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -52,7 +76,7 @@ func TestNilDate(t *testing.T) {
 		name string
 		want Date
 	}{
-		{"Nil", Date{v: "", present: false, initialized: true}},
+		{"Nil", NilDate()},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -79,6 +103,16 @@ func TestNewDateFromTime(t *testing.T) {
 			give: NewTime(time.Date(2019, 9, 20, 0, 0, 0, 0, time.Local)),
 			want: NewDate("2019-09-20"),
 		},
+		{
+			name: "Infinite Time",
+			give: NewInfiniteTime(),
+			want: NewInfiniteDate(),
+		},
+		{
+			name: "Negative Infinite Time",
+			give: NewNegInfiniteTime(),
+			want: NewNegInfiniteDate(),
+		},
 		{
 			name: "Valid Time with minutes",
 			give: NewTime(time.Date(2019, 7, 11, 12, 30, 0, 0, time.Local)),
@@ -107,7 +141,7 @@ func TestDate_Nil(t *testing.T) {
 		},
 		{
 			name: "Not Nil",
-			give: Date{v: "", present: true, initialized: true},
+			give: NewDate("2019-11-12"),
 			want: false,
 		},
 	}
@@ -134,26 +168,26 @@ func TestDate_DaysAgo(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "Invalid Format",
-			give:    Date{v: "2019/11/12", present: true, initialized: true},
+			name:    "Infinity",
+			give:    NewInfiniteDate(),
 			want:    Int64{},
 			wantErr: true,
 		},
 		{
 			name:    "One Day Ago",
-			give:    Date{v: daysAgo(1), present: true, initialized: true},
+			give:    NewDate(daysAgo(1)),
 			want:    Int64{v: 1, present: true, initialized: true},
 			wantErr: false,
 		},
 		{
 			name:    "30 Days Ago",
-			give:    Date{v: daysAgo(30), present: true, initialized: true},
+			give:    NewDate(daysAgo(30)),
 			want:    Int64{v: 30, present: true, initialized: true},
 			wantErr: false,
 		},
 		{
 			name:    "Zero Days Ago",
-			give:    Date{v: daysAgo(0), present: true, initialized: true},
+			give:    NewDate(daysAgo(0)),
 			want:    Int64{v: 0, present: true, initialized: true},
 			wantErr: false,
 		},
@@ -183,25 +217,25 @@ func TestDate_String(t *testing.T) {
 		give Date
 		want string
 	}{
-		{
-			name: "Non-nil Empty",
-			give: Date{v: "", present: true, initialized: true},
-			want: "",
-		},
 		{
 			name: "Nil",
-			give: Date{v: "", present: false, initialized: true},
+			give: NilDate(),
 			want: "",
 		},
 		{
 			name: "Valid Format",
-			give: Date{v: "2019-11-12", present: true, initialized: true},
+			give: NewDate("2019-11-12"),
 			want: "2019-11-12",
 		},
 		{
-			name: "Invalid Format",
-			give: Date{v: "11/12/2019", present: true, initialized: true},
-			want: "11/12/2019",
+			name: "Zero Padded",
+			give: NewDate("0099-01-02"),
+			want: "0099-01-02",
+		},
+		{
+			name: "Infinity",
+			give: NewInfiniteDate(),
+			want: "infinity",
 		},
 	}
 	for _, tt := range tests {
@@ -223,7 +257,19 @@ func TestDate_UnmarshalJSON(t *testing.T) {
 		{
 			name:    "String (Valid Format)",
 			give:    toBytes("2016-01-31"),
-			want:    Date{v: "2016-01-31", present: true, initialized: true},
+			want:    NewDate("2016-01-31"),
+			wantErr: false,
+		},
+		{
+			name:    "String (Infinity)",
+			give:    toBytes("infinity"),
+			want:    NewDate("infinity"),
+			wantErr: false,
+		},
+		{
+			name:    "String (Negative Infinity)",
+			give:    toBytes("-infinity"),
+			want:    NewDate("-infinity"),
 			wantErr: false,
 		},
 		{
@@ -232,6 +278,24 @@ func TestDate_UnmarshalJSON(t *testing.T) {
 			want:    Date{},
 			wantErr: true,
 		},
+		{
+			name:    "String (February 30th)",
+			give:    toBytes("2016-02-30"),
+			want:    Date{},
+			wantErr: true,
+		},
+		{
+			name:    "String (13th Month)",
+			give:    toBytes("2016-13-01"),
+			want:    Date{},
+			wantErr: true,
+		},
+		{
+			name:    "String (Trailing Digits)",
+			give:    toBytes("2000-12-3456789"),
+			want:    Date{},
+			wantErr: true,
+		},
 		{
 			name:    "Floating Point Number",
 			give:    toBytes(3.14159),
@@ -253,7 +317,7 @@ func TestDate_UnmarshalJSON(t *testing.T) {
 		{
 			name:    "Null",
 			give:    toBytes(nil),
-			want:    Date{v: "", present: false, initialized: true},
+			want:    NilDate(),
 			wantErr: false,
 		},
 	}
@@ -280,25 +344,25 @@ func TestDate_MarshalJSON(t *testing.T) {
 	}{
 		{
 			name:    "Present and Initialized (Valid Format)",
-			give:    Date{v: "2019-11-12", present: true, initialized: true},
+			give:    NewDate("2019-11-12"),
 			want:    toBytes("2019-11-12"),
 			wantErr: false,
 		},
 		{
-			name:    "Present and Initialized (Invalid Format)",
-			give:    Date{v: "2019/11/12", present: true, initialized: true},
-			want:    toBytes("2019/11/12"),
+			name:    "Present and Initialized (Infinity)",
+			give:    NewInfiniteDate(),
+			want:    toBytes("infinity"),
 			wantErr: false,
 		},
 		{
 			name:    "Not Present",
-			give:    Date{v: "2019-11-12", present: false, initialized: true},
+			give:    NilDate(),
 			want:    toBytes(nil),
 			wantErr: false,
 		},
 		{
 			name:    "Not Initialized",
-			give:    Date{v: "2019-11-12", present: true, initialized: false},
+			give:    Date{},
 			want:    toBytes(nil),
 			wantErr: false,
 		},
@@ -332,7 +396,7 @@ func TestDate_Value(t *testing.T) {
 		},
 		{
 			name:    "Not Nil",
-			give:    Date{v: "2018-07-11", present: true, initialized: true},
+			give:    NewDate("2018-07-11"),
 			want:    "2018-07-11",
 			wantErr: false,
 		},
@@ -387,12 +451,42 @@ func TestDate_Scan(t *testing.T) {
 		{
 			name:    "String",
 			give:    "2019-10-01",
+			want:    NewDate("2019-10-01"),
+			wantErr: false,
+		},
+		{
+			name:    "Timestamptz Text",
+			give:    []byte("2019-10-01 23:30:00.123456-07"),
+			want:    NewDate("2019-10-01"),
+			wantErr: false,
+		},
+		{
+			name:    "RFC3339 Text",
+			give:    "2019-10-01T10:30:00Z",
+			want:    NewDate("2019-10-01"),
+			wantErr: false,
+		},
+		{
+			name:    "Invalid Text",
+			give:    "2019-02-30",
 			wantErr: true,
 		},
+		{
+			name:    "Infinity",
+			give:    "infinity",
+			want:    NewDate("infinity"),
+			wantErr: false,
+		},
+		{
+			name:    "Negative Infinity",
+			give:    "-infinity",
+			want:    NewDate("-infinity"),
+			wantErr: false,
+		},
 		{
 			name:    "Time",
 			give:    time.Date(2011 /* year */, 12 /* month */, 12 /* day */, 0 /* hour */, 0 /* min */, 0 /* sec */, 0 /* nsec */, time.UTC),
-			want:    Date{v: "2011-12-12", present: true, initialized: true},
+			want:    NewDate("2011-12-12"),
 			wantErr: false,
 		},
 	}
@@ -409,3 +503,370 @@ func TestDate_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestDate_IsInfinite(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       Date
+		wantInf    bool
+		wantNegInf bool
+	}{
+		{"Nil", NilDate(), false, false},
+		{"Finite", NewDate("2019-11-12"), false, false},
+		{"Infinity", NewInfiniteDate(), true, false},
+		{"Negative Infinity", NewNegInfiniteDate(), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.IsInfinite(); got != tt.wantInf {
+				t.Errorf("Date.IsInfinite() = %v, want %v", got, tt.wantInf)
+			}
+			if got := tt.give.IsNegInfinite(); got != tt.wantNegInf {
+				t.Errorf("Date.IsNegInfinite() = %v, want %v", got, tt.wantNegInf)
+			}
+		})
+	}
+}
+
+func TestDate_ScanDate(t *testing.T) {
+	tests := []struct {
+		name string
+		give pgtype.Date
+		want Date
+	}{
+		{
+			name: "Null",
+			give: pgtype.Date{},
+			want: Date{present: false, initialized: true},
+		},
+		{
+			name: "Date",
+			give: pgtype.Date{Time: time.Date(2019, 11, 12, 0, 0, 0, 0, time.UTC), Valid: true},
+			want: NewDate("2019-11-12"),
+		},
+		{
+			name: "Infinity",
+			give: pgtype.Date{InfinityModifier: pgtype.Infinity, Valid: true},
+			want: NewDate("infinity"),
+		},
+		{
+			name: "Negative Infinity",
+			give: pgtype.Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+			want: NewDate("-infinity"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &Date{}
+			if err := got.ScanDate(tt.give); err != nil {
+				t.Errorf("Date.ScanDate() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Date.ScanDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_DateValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    Date
+		want    pgtype.Date
+		wantErr bool
+	}{
+		{
+			name: "Nil",
+			give: NilDate(),
+			want: pgtype.Date{},
+		},
+		{
+			name: "Date",
+			give: NewDate("2019-11-12"),
+			want: pgtype.Date{Time: time.Date(2019, 11, 12, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			name: "Infinity",
+			give: NewInfiniteDate(),
+			want: pgtype.Date{InfinityModifier: pgtype.Infinity, Valid: true},
+		},
+		{
+			name: "Negative Infinity",
+			give: NewNegInfiniteDate(),
+			want: pgtype.Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.DateValue()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Date.DateValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.DateValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_AddDays(t *testing.T) {
+	tests := []struct {
+		name string
+		give Date
+		n    int
+		want Date
+	}{
+		{"Nil", NilDate(), 1, NilDate()},
+		{"Infinity", NewInfiniteDate(), 1, NewInfiniteDate()},
+		{"Next Day", NewDate("2019-11-12"), 1, NewDate("2019-11-13")},
+		{"Across a Year", NewDate("2019-12-31"), 1, NewDate("2020-01-01")},
+		{"Leap Day", NewDate("2020-02-28"), 1, NewDate("2020-02-29")},
+		{"Backwards", NewDate("2020-03-01"), -1, NewDate("2020-02-29")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.AddDays(tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.AddDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_AddMonths(t *testing.T) {
+	tests := []struct {
+		name string
+		give Date
+		n    int
+		want Date
+	}{
+		{"Nil", NilDate(), 1, NilDate()},
+		{"Negative Infinity", NewNegInfiniteDate(), 1, NewNegInfiniteDate()},
+		{"Next Month", NewDate("2019-11-12"), 1, NewDate("2019-12-12")},
+		{"Across a Year", NewDate("2019-11-12"), 3, NewDate("2020-02-12")},
+		{"Clamped", NewDate("2019-01-31"), 1, NewDate("2019-02-28")},
+		{"Clamped in a Leap Year", NewDate("2020-01-31"), 1, NewDate("2020-02-29")},
+		{"Backwards", NewDate("2020-03-31"), -13, NewDate("2019-02-28")},
+		{"Backwards to December", NewDate("2020-01-15"), -1, NewDate("2019-12-15")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.AddMonths(tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.AddMonths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_AddYears(t *testing.T) {
+	tests := []struct {
+		name string
+		give Date
+		n    int
+		want Date
+	}{
+		{"Nil", NilDate(), 1, NilDate()},
+		{"Next Year", NewDate("2019-11-12"), 1, NewDate("2020-11-12")},
+		{"Leap Day", NewDate("2020-02-29"), 1, NewDate("2021-02-28")},
+		{"Leap Day to Leap Year", NewDate("2020-02-29"), -4, NewDate("2016-02-29")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.AddYears(tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.AddYears() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_StartAndEndOfMonth(t *testing.T) {
+	tests := []struct {
+		name      string
+		give      Date
+		wantStart Date
+		wantEnd   Date
+	}{
+		{"Nil", NilDate(), NilDate(), NilDate()},
+		{"Infinity", NewInfiniteDate(), NewInfiniteDate(), NewInfiniteDate()},
+		{"November", NewDate("2019-11-12"), NewDate("2019-11-01"), NewDate("2019-11-30")},
+		{"December", NewDate("2019-12-31"), NewDate("2019-12-01"), NewDate("2019-12-31")},
+		{"Leap February", NewDate("2020-02-01"), NewDate("2020-02-01"), NewDate("2020-02-29")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.StartOfMonth(); !reflect.DeepEqual(got, tt.wantStart) {
+				t.Errorf("Date.StartOfMonth() = %v, want %v", got, tt.wantStart)
+			}
+			if got := tt.give.EndOfMonth(); !reflect.DeepEqual(got, tt.wantEnd) {
+				t.Errorf("Date.EndOfMonth() = %v, want %v", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestDate_DaysBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    Date
+		y       Date
+		want    Int64
+		wantErr bool
+	}{
+		{"Nil", NilDate(), NewDate("2019-11-12"), Int64{}, false},
+		{"Nil Argument", NewDate("2019-11-12"), NilDate(), Int64{}, false},
+		{"Infinity", NewDate("2019-11-12"), NewInfiniteDate(), Int64{}, true},
+		{"Same Day", NewDate("2019-11-12"), NewDate("2019-11-12"), NewInt64(0), false},
+		{"Leap Year", NewDate("2020-01-01"), NewDate("2021-01-01"), NewInt64(366), false},
+		{"Earlier", NewDate("2019-11-12"), NewDate("2019-11-02"), NewInt64(-10), false},
+		{"Centuries", NewDate("1600-01-01"), NewDate("2000-01-01"), NewInt64(146097), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.DaysBetween(tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Date.DaysBetween() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.DaysBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_YearsBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    Date
+		y       Date
+		want    Int64
+		wantErr bool
+	}{
+		{"Nil", NilDate(), NewDate("2019-11-12"), Int64{}, false},
+		{"Negative Infinity", NewNegInfiniteDate(), NewDate("2019-11-12"), Int64{}, true},
+		{"Day Before Anniversary", NewDate("2000-11-12"), NewDate("2019-11-11"), NewInt64(18), false},
+		{"Anniversary", NewDate("2000-11-12"), NewDate("2019-11-12"), NewInt64(19), false},
+		{"Leap Day in a Common Year", NewDate("2000-02-29"), NewDate("2001-02-28"), NewInt64(0), false},
+		{"Leap Day After February", NewDate("2000-02-29"), NewDate("2001-03-01"), NewInt64(1), false},
+		{"Earlier", NewDate("2019-11-12"), NewDate("2000-11-13"), NewInt64(-18), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.YearsBetween(tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Date.YearsBetween() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.YearsBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_Compare(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       Date
+		y          Date
+		wantBefore bool
+		wantAfter  bool
+		wantEqual  bool
+	}{
+		{"Nil", NilDate(), NilDate(), false, false, false},
+		{"Nil Argument", NewDate("2019-11-12"), NilDate(), false, false, false},
+		{"Earlier", NewDate("2019-11-11"), NewDate("2019-11-12"), true, false, false},
+		{"Later", NewDate("2020-01-01"), NewDate("2019-12-31"), false, true, false},
+		{"Same Day", NewDate("2019-11-12"), NewDate("2019-11-12"), false, false, true},
+		{"Infinity", NewInfiniteDate(), NewDate("9999-12-31"), false, true, false},
+		{"Negative Infinity", NewNegInfiniteDate(), NewDate("0001-01-01"), true, false, false},
+		{"Both Infinity", NewInfiniteDate(), NewInfiniteDate(), false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.Before(tt.y); got != tt.wantBefore {
+				t.Errorf("Date.Before() = %v, want %v", got, tt.wantBefore)
+			}
+			if got := tt.give.After(tt.y); got != tt.wantAfter {
+				t.Errorf("Date.After() = %v, want %v", got, tt.wantAfter)
+			}
+			if got := tt.give.Equal(tt.y); got != tt.wantEqual {
+				t.Errorf("Date.Equal() = %v, want %v", got, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestDate_WeekdayAndQuarter(t *testing.T) {
+	tests := []struct {
+		name        string
+		give        Date
+		wantWeekday Nillable[time.Weekday]
+		wantQuarter Int64
+	}{
+		{"Nil", NilDate(), Nillable[time.Weekday]{}, Int64{}},
+		{"Infinity", NewInfiniteDate(), Nillable[time.Weekday]{}, Int64{}},
+		{"First Quarter", NewDate("2020-03-31"), New(time.Tuesday), NewInt64(1)},
+		{"Second Quarter", NewDate("2020-04-01"), New(time.Wednesday), NewInt64(2)},
+		{"Fourth Quarter", NewDate("2019-11-12"), New(time.Tuesday), NewInt64(4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.Weekday(); !reflect.DeepEqual(got, tt.wantWeekday) {
+				t.Errorf("Date.Weekday() = %v, want %v", got, tt.wantWeekday)
+			}
+			if got := tt.give.Quarter(); !reflect.DeepEqual(got, tt.wantQuarter) {
+				t.Errorf("Date.Quarter() = %v, want %v", got, tt.wantQuarter)
+			}
+		})
+	}
+}
+
+func TestDate_DaysAgoAt(t *testing.T) {
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		give    Date
+		now     time.Time
+		loc     *time.Location
+		want    Int64
+		wantErr bool
+	}{
+		{"Nil", NilDate(), time.Now(), nil, Int64{}, false},
+		{"Infinity", NewInfiniteDate(), time.Now(), nil, Int64{}, true},
+		{"Same Day", NewDate("2019-11-12"), time.Date(2019, 11, 12, 23, 59, 0, 0, time.UTC), nil, NewInt64(0), false},
+		{"Future", NewDate("2019-11-13"), time.Date(2019, 11, 12, 23, 59, 0, 0, time.UTC), nil, NewInt64(-1), false},
+		{"Now's Location", NewDate("2019-11-12"), time.Date(2019, 11, 13, 5, 0, 0, 0, time.UTC), nil, NewInt64(1), false},
+		{"Other Location", NewDate("2019-11-12"), time.Date(2019, 11, 13, 5, 0, 0, 0, time.UTC), pacific, NewInt64(0), false},
+		{"Spring Forward", NewDate("2019-03-10"), time.Date(2019, 3, 11, 0, 30, 0, 0, pacific), nil, NewInt64(1), false},
+		{"Fall Back", NewDate("2019-11-02"), time.Date(2019, 11, 4, 0, 30, 0, 0, pacific), nil, NewInt64(2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.DaysAgoAt(tt.now, tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Date.DaysAgoAt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date.DaysAgoAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_ScanParseError(t *testing.T) {
+	got := &Date{}
+	err := got.Scan([]byte("2019-02-30"))
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Date.Scan() error = %v, want a *ParseError", err)
+	}
+	if pe.Type != "date" || pe.Input != "2019-02-30" {
+		t.Errorf("Date.Scan() error = %#v", pe)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+)
+
+// DateLayouts lists the time.Parse layouts that Dates are parsed from, in the
+// order they're tried. Whichever layout a Date is parsed from, it is stored
+// as a calendar date and always formatted as ISO 8601. Timestamp layouts such
+// as time.RFC3339 take the date in the timestamp's own offset. For example,
+// to accept the formats found in county recorder data:
+//
+//	nillabletypes.SetDateLayouts(nillabletypes.DateLayouts{
+//		time.DateOnly, "01/02/2006", "20060102", "02-Jan-06", time.RFC3339,
+//	})
+//
+// The layouts should usually include time.DateOnly, so that Dates marshaled
+// by this package can be parsed again.
+type DateLayouts []string
+
+// isoDateLayouts accepts ISO 8601 dates such as "2019-11-12" only. It is used
+// when no layouts are set, and by NewDate regardless of them.
+var isoDateLayouts = DateLayouts{time.DateOnly}
+
+var defaultDateLayouts atomic.Pointer[DateLayouts]
+
+// DefaultDateLayouts returns the layouts used when none are set with
+// SetDateLayouts, which accept ISO 8601 dates such as "2019-11-12" only
+func DefaultDateLayouts() DateLayouts {
+	return slices.Clone(isoDateLayouts)
+}
+
+// SetDateLayouts sets the layouts that ParseDate, Date.UnmarshalJSON and
+// Date.Scan accept. Use DateLayouts.Unmarshaler and DateLayouts.Scanner
+// instead to accept different layouts from different sources. Setting nil
+// restores DefaultDateLayouts.
+func SetDateLayouts(l DateLayouts) {
+	if l == nil {
+		defaultDateLayouts.Store(nil)
+		return
+	}
+	l = slices.Clone(l)
+	defaultDateLayouts.Store(&l)
+}
+
+// CurrentDateLayouts returns a copy of the layouts set with SetDateLayouts
+func CurrentDateLayouts() DateLayouts {
+	return slices.Clone(currentDateLayouts())
+}
+
+// currentDateLayouts returns the layouts set with SetDateLayouts without
+// copying them; callers must not modify the result
+func currentDateLayouts() DateLayouts {
+	if l := defaultDateLayouts.Load(); l != nil {
+		return *l
+	}
+	return isoDateLayouts
+}
+
+// Parse makes a new non-nil Date from v in the first of these layouts that it
+// matches, or from "infinity" or "-infinity". The error for a value that
+// matches none of them names the layouts that were tried.
+func (l DateLayouts) Parse(v string) (Date, error) {
+	switch v {
+	case dateInfinity:
+		return NewInfiniteDate(), nil
+	case dateNegInfinity:
+		return NewNegInfiniteDate(), nil
+	}
+	for _, layout := range l {
+		if t, err := time.Parse(layout, v); err == nil {
+			return Date(New(civilDateOf(t))), nil
+		}
+	}
+	return Date{}, errors.Errorf("value %q is not a valid date in any of the layouts %s", v, l.quoted())
+}
+
+// quoted returns these layouts quoted and separated by commas, for error
+// messages
+func (l DateLayouts) quoted() string {
+	q := make([]string, len(l))
+	for i, layout := range l {
+		q[i] = strconv.Quote(layout)
+	}
+	return strings.Join(q, ", ")
+}
+
+type dateLayoutsUnmarshaler struct {
+	layouts DateLayouts
+	dst     *Date
+}
+
+func (u *dateLayoutsUnmarshaler) UnmarshalJSON(data []byte) error {
+	return u.dst.unmarshalJSON(u.layouts, data)
+}
+
+// Unmarshaler returns a json.Unmarshaler that decodes into dst using these
+// layouts regardless of the ones set with SetDateLayouts, e.g.
+//
+//	json.Unmarshal(data, layouts.Unmarshaler(&listed))
+func (l DateLayouts) Unmarshaler(dst *Date) json.Unmarshaler {
+	return &dateLayoutsUnmarshaler{layouts: l, dst: dst}
+}
+
+// Scanner returns a sql.Scanner that scans into dst using these layouts
+// regardless of the ones set with SetDateLayouts
+func (l DateLayouts) Scanner(dst *Date) sql.Scanner {
+	return dialectScanFunc(func(src any) error {
+		return dst.scanLayouts(l, src)
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+var countyDateLayouts = DateLayouts{time.DateOnly, "01/02/2006", "20060102", "02-Jan-06", time.RFC3339}
+
+func TestDateLayouts_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Date
+		wantErr bool
+	}{
+		{"ISO", "2019-11-12", NewDate("2019-11-12"), false},
+		{"US", "11/12/2019", NewDate("2019-11-12"), false},
+		{"Compact", "20191112", NewDate("2019-11-12"), false},
+		{"Day Month Year", "12-Nov-19", NewDate("2019-11-12"), false},
+		{"Upper Case Month", "12-NOV-19", NewDate("2019-11-12"), false},
+		{"RFC3339", "2019-11-12T23:30:00-08:00", NewDate("2019-11-12"), false},
+		{"Infinity", "infinity", NewInfiniteDate(), false},
+		{"February 30th", "02/30/2019", Date{}, true},
+		{"Unknown Layout", "2019.11.12", Date{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := countyDateLayouts.Parse(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateLayouts_ParseError(t *testing.T) {
+	_, err := DateLayouts{time.DateOnly, "01/02/2006"}.Parse("2019.11.12")
+	assert.EqualError(t, err, `value "2019.11.12" is not a valid date in any of the layouts "2006-01-02", "01/02/2006"`)
+}
+
+func TestDateLayouts_Copies(t *testing.T) {
+	defer SetDateLayouts(nil)
+
+	DefaultDateLayouts()[0] = "01/02/2006"
+	CurrentDateLayouts()[0] = "01/02/2006"
+	assert.Equal(t, DateLayouts{time.DateOnly}, CurrentDateLayouts())
+
+	l := DateLayouts{time.DateOnly}
+	SetDateLayouts(l)
+	l[0] = "01/02/2006"
+	CurrentDateLayouts()[0] = "01/02/2006"
+	assert.Equal(t, DateLayouts{time.DateOnly}, CurrentDateLayouts())
+
+	_, err := ParseDate("11/12/2019")
+	assert.Error(t, err)
+}
+
+func TestSetDateLayouts(t *testing.T) {
+	defer SetDateLayouts(nil)
+
+	assert.Equal(t, DefaultDateLayouts(), CurrentDateLayouts())
+	_, err := ParseDate("11/12/2019")
+	assert.Error(t, err)
+
+	SetDateLayouts(countyDateLayouts)
+	assert.Equal(t, countyDateLayouts, CurrentDateLayouts())
+
+	got, err := ParseDate("11/12/2019")
+	assert.NoError(t, err)
+	assert.Equal(t, NewDate("2019-11-12"), got)
+	assert.PanicsWithError(t, `NewDate only accepts ISO 8601 dates, use ParseDate for other layouts: value "11/12/2019" is not a valid date in any of the layouts "2006-01-02"`, func() {
+		NewDate("11/12/2019")
+	})
+
+	var d Date
+	assert.NoError(t, json.Unmarshal([]byte(`"20191112"`), &d))
+	assert.Equal(t, NewDate("2019-11-12"), d)
+	b, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2019-11-12"`, string(b))
+
+	SetDateLayouts(nil)
+	assert.Equal(t, DefaultDateLayouts(), CurrentDateLayouts())
+}
+
+func TestDateLayouts_Unmarshaler(t *testing.T) {
+	var got Date
+	assert.NoError(t, json.Unmarshal([]byte(`"12-Nov-19"`), countyDateLayouts.Unmarshaler(&got)))
+	assert.Equal(t, NewDate("2019-11-12"), got)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), countyDateLayouts.Unmarshaler(&got)))
+	assert.Equal(t, NilDate(), got)
+
+	assert.Error(t, json.Unmarshal([]byte(`"12-Nov-19"`), DefaultDateLayouts().Unmarshaler(&got)))
+}
+
+func TestDateLayouts_Scanner(t *testing.T) {
+	var got Date
+	assert.NoError(t, countyDateLayouts.Scanner(&got).Scan([]byte("11/12/2019")))
+	assert.Equal(t, NewDate("2019-11-12"), got)
+
+	assert.NoError(t, countyDateLayouts.Scanner(&got).Scan("2019-11-13 10:30:00"))
+	assert.Equal(t, NewDate("2019-11-13"), got)
+
+	err := DefaultDateLayouts().Scanner(&got).Scan("11/12/2019")
+	assert.ErrorContains(t, err, `not in any of the layouts "2006-01-02", "2006-01-02T15:04:05Z07:00"`)
+}
@@ -0,0 +1,463 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+)
+
+// maxDecimalScale and minDecimalScale bound the scale of a Decimal to that of
+// a PostgreSQL NUMERIC: 16383 digits after the decimal point and 131072
+// before it
+const (
+	maxDecimalScale = 16383
+	minDecimalScale = -131072
+)
+
+// RoundingMode selects how a Decimal is rounded when digits are dropped
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value, and ties away from zero
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest value, and ties to an even digit
+	RoundHalfEven
+	// RoundHalfDown rounds to the nearest value, and ties toward zero
+	RoundHalfDown
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundDown rounds toward zero, i.e. truncates
+	RoundDown
+	// RoundCeiling rounds toward positive infinity
+	RoundCeiling
+	// RoundFloor rounds toward negative infinity
+	RoundFloor
+)
+
+// String implements the fmt.Stringer interface
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfUp:
+		return "RoundHalfUp"
+	case RoundHalfEven:
+		return "RoundHalfEven"
+	case RoundHalfDown:
+		return "RoundHalfDown"
+	case RoundUp:
+		return "RoundUp"
+	case RoundDown:
+		return "RoundDown"
+	case RoundCeiling:
+		return "RoundCeiling"
+	case RoundFloor:
+		return "RoundFloor"
+	}
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// decimal is the value of a Decimal: unscaled * 10^-scale. unscaled is never
+// modified once a decimal has been made.
+type decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// Decimal represents a nil-able fixed-point decimal number of arbitrary
+// precision, such as a PostgreSQL NUMERIC. It keeps its scale, so "1.50"
+// round-trips as "1.50" rather than "1.5".
+type Decimal Nillable[decimal]
+
+// NewDecimal makes a new non-nil Decimal of unscaled * 10^-scale, e.g.
+// NewDecimal(12345, 2) is 123.45
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// NewDecimalFromBigInt makes a new non-nil Decimal of unscaled * 10^-scale
+func NewDecimalFromBigInt(unscaled *big.Int, scale int32) Decimal {
+	return newDecimal(new(big.Int).Set(unscaled), scale)
+}
+
+// NilDecimal makes a new nil Decimal
+func NilDecimal() Decimal {
+	return Decimal(Nil[decimal]())
+}
+
+// ParseDecimal parses a decimal number such as "123.45", "-0.5" or "1e3"
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, errors.Errorf("cannot parse %q as a decimal", s)
+		}
+	}
+
+	digits := mantissa
+	if digits != "" && (digits[0] == '-' || digits[0] == '+') {
+		digits = digits[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart+fracPart == "" || strings.Trim(intPart+fracPart, "0123456789") != "" {
+		return Decimal{}, errors.Errorf("cannot parse %q as a decimal", s)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale || scale < minDecimalScale {
+		return Decimal{}, errors.Errorf("decimal %q is outside of the range of NUMERIC", s)
+	}
+	unscaled, _ := new(big.Int).SetString(intPart+fracPart, 10)
+	if mantissa[0] == '-' {
+		unscaled.Neg(unscaled)
+	}
+	return newDecimal(unscaled, int32(scale)), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s can't be parsed
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// newDecimal makes a Decimal from an unscaled value it takes ownership of,
+// normalizing it so that equal Decimals are deeply equal
+func newDecimal(unscaled *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	if unscaled.Sign() == 0 {
+		unscaled = new(big.Int)
+	}
+	return Decimal(New(decimal{unscaled: unscaled, scale: scale}))
+}
+
+// Unscaled returns the unscaled value of this Decimal, i.e. the value times
+// 10^Scale()
+func (v Decimal) Unscaled() *big.Int {
+	if v.v.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(v.v.unscaled)
+}
+
+// Scale returns the number of digits after the decimal point
+func (v Decimal) Scale() int32 {
+	return v.v.scale
+}
+
+// Rat returns the value of this Decimal as a big.Rat
+func (v Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(v.Unscaled(), pow10(v.v.scale))
+}
+
+// Float returns the nearest float64 to the value of this Decimal
+func (v Decimal) Float() float64 {
+	f, _ := v.Rat().Float64()
+	return f
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of this Decimal. Nil is 0.
+func (v Decimal) Sign() int {
+	if v.v.unscaled == nil {
+		return 0
+	}
+	return v.v.unscaled.Sign()
+}
+
+// Nil returns whether this scalar is nil
+func (v Decimal) Nil() bool {
+	return Nillable[decimal](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Decimal) Initialized() bool {
+	return Nillable[decimal](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Decimal) Set() bool {
+	return Nillable[decimal](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Decimal) State() State {
+	return Nillable[decimal](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Decimal) Reset() {
+	(*Nillable[decimal])(v).Reset()
+}
+
+// String implements the fmt.Stringer interface. The result has exactly Scale()
+// digits after the decimal point.
+func (v Decimal) String() string {
+	s := v.Unscaled().String()
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if scale := int(v.v.scale); scale > 0 {
+		if len(s) <= scale {
+			s = strings.Repeat("0", scale-len(s)+1) + s
+		}
+		s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Cmp compares this Decimal to y and returns -1, 0 or +1. Nil compares as
+// zero.
+func (v Decimal) Cmp(y Decimal) int {
+	a, b := alignDecimals(v, y)
+	return a.Cmp(b)
+}
+
+// Add returns the exact sum of this Decimal and y, or nil if either is nil
+func (v Decimal) Add(y Decimal) Decimal {
+	if !v.present || !y.present {
+		return NilDecimal()
+	}
+	a, b := alignDecimals(v, y)
+	return newDecimal(a.Add(a, b), max(v.v.scale, y.v.scale))
+}
+
+// Sub returns the exact difference of this Decimal and y, or nil if either is
+// nil
+func (v Decimal) Sub(y Decimal) Decimal {
+	if !v.present || !y.present {
+		return NilDecimal()
+	}
+	a, b := alignDecimals(v, y)
+	return newDecimal(a.Sub(a, b), max(v.v.scale, y.v.scale))
+}
+
+// Mul returns the exact product of this Decimal and y, whose scale is the sum
+// of theirs, or nil if either is nil
+func (v Decimal) Mul(y Decimal) Decimal {
+	if !v.present || !y.present {
+		return NilDecimal()
+	}
+	return newDecimal(new(big.Int).Mul(v.Unscaled(), y.Unscaled()), v.v.scale+y.v.scale)
+}
+
+// Neg returns the negation of this Decimal
+func (v Decimal) Neg() Decimal {
+	if !v.present {
+		return v
+	}
+	u := v.Unscaled()
+	return newDecimal(u.Neg(u), v.v.scale)
+}
+
+// Quo returns this Decimal divided by y, rounded to scale digits after the
+// decimal point with mode, or nil if either is nil. It returns an error if y
+// is zero.
+func (v Decimal) Quo(y Decimal, scale int32, mode RoundingMode) (Decimal, error) {
+	if !v.present || !y.present {
+		return NilDecimal(), nil
+	}
+	if y.Sign() == 0 {
+		return Decimal{}, errors.New("decimal division by zero")
+	}
+	// v/y * 10^scale = v.unscaled * 10^(y.scale+scale-v.scale) / y.unscaled
+	num, den := v.Unscaled(), y.Unscaled()
+	if e := int64(y.v.scale) + int64(scale) - int64(v.v.scale); e >= 0 {
+		num.Mul(num, pow10(int32(e)))
+	} else {
+		den.Mul(den, pow10(int32(-e)))
+	}
+	return newDecimal(quoRound(num, den, mode), scale), nil
+}
+
+// Round returns this Decimal rounded to scale digits after the decimal point
+// with mode. Rounding to a larger scale pads it with zeros.
+func (v Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if !v.present {
+		return v
+	}
+	if scale >= v.v.scale {
+		return newDecimal(v.Unscaled().Mul(v.v.unscaled, pow10(scale-v.v.scale)), scale)
+	}
+	return newDecimal(quoRound(v.Unscaled(), pow10(v.v.scale-scale), mode), scale)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts both a
+// string such as "123.45" and a number, which is decoded exactly.
+func (v *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		*v = NilDecimal()
+		return nil
+	}
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.WithStack(err)
+		}
+	} else {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return errors.WithStack(err)
+		}
+		s = string(n)
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*v = d
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Decimals are marshaled
+// as strings, e.g. "123.45", so that JSON decoders don't round them.
+func (v Decimal) MarshalJSON() ([]byte, error) {
+	if !v.initialized || !v.present {
+		return []byte{'n', 'u', 'l', 'l'}, nil
+	}
+	return json.Marshal(v.String())
+}
+
+// Value implements the driver.Valuer interface, returning the value as a
+// string
+func (v Decimal) Value() (driver.Value, error) { //nolint:unparam
+	if !v.present {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Decimal) Scan(src interface{}) error {
+	switch t := src.(type) {
+	case nil:
+		*v = NilDecimal()
+		return nil
+	case int64:
+		*v = NewDecimal(t, 0)
+		return nil
+	case float64:
+		return v.scanString(strconv.FormatFloat(t, 'f', -1, 64))
+	case []byte:
+		return v.scanString(string(t))
+	case string:
+		return v.scanString(t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to a decimal", src)
+}
+
+func (v *Decimal) scanString(src string) error {
+	d, err := ParseDecimal(src)
+	if err != nil {
+		return err
+	}
+	*v = d
+	return nil
+}
+
+// ScanNumeric implements the pgtype.NumericScanner interface
+func (v *Decimal) ScanNumeric(src pgtype.Numeric) error {
+	switch {
+	case !src.Valid:
+		*v = NilDecimal()
+		return nil
+	case src.NaN:
+		return errors.New("cannot scan NaN to a decimal")
+	case src.InfinityModifier != pgtype.Finite:
+		return errors.Errorf("cannot scan %v to a decimal", src.InfinityModifier)
+	case src.Int == nil:
+		*v = NewDecimal(0, -src.Exp)
+		return nil
+	}
+	*v = NewDecimalFromBigInt(src.Int, -src.Exp)
+	return nil
+}
+
+// NumericValue implements the pgtype.NumericValuer interface
+func (v Decimal) NumericValue() (pgtype.Numeric, error) { //nolint:unparam
+	if !v.present {
+		return pgtype.Numeric{}, nil
+	}
+	return pgtype.Numeric{Int: v.Unscaled(), Exp: -v.v.scale, Valid: true}, nil
+}
+
+// alignDecimals returns the unscaled values of x and y at the larger of their
+// scales
+func alignDecimals(x, y Decimal) (*big.Int, *big.Int) {
+	a, b := x.Unscaled(), y.Unscaled()
+	switch {
+	case x.v.scale < y.v.scale:
+		a.Mul(a, pow10(y.v.scale-x.v.scale))
+	case x.v.scale > y.v.scale:
+		b.Mul(b, pow10(x.v.scale-y.v.scale))
+	}
+	return a, b
+}
+
+// quoRound returns num/den rounded to an integer with mode
+func quoRound(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// sign is the sign of the exact quotient, which q truncated toward zero
+	sign := num.Sign() * den.Sign()
+	// half compares the remainder to half of the divisor
+	r.Abs(r)
+	half := r.Lsh(r, 1).Cmp(new(big.Int).Abs(den))
+
+	var away bool
+	switch mode {
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundHalfEven:
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	case RoundHalfDown:
+		away = half > 0
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
+
+// pow10 returns 10^n for n >= 0
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,348 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecimal(t *testing.T) {
+	assert.Equal(t, "123.45", NewDecimal(12345, 2).String())
+	assert.Equal(t, "-0.05", NewDecimal(-5, 2).String())
+	assert.Equal(t, "1200", NewDecimal(12, -2).String())
+	assert.Equal(t, int32(0), NewDecimal(12, -2).Scale())
+	assert.Equal(t, NewDecimal(0, 2), MustParseDecimal("0.00"))
+	assert.Equal(t, StateValue, NewDecimal(0, 0).State())
+}
+
+func TestNewDecimalFromBigInt(t *testing.T) {
+	i, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	d := NewDecimalFromBigInt(i, 10)
+	assert.Equal(t, "12345678901234567890.1234567890", d.String())
+
+	// The Decimal doesn't alias its argument
+	i.SetInt64(1)
+	assert.Equal(t, "12345678901234567890.1234567890", d.String())
+	d.Unscaled().SetInt64(1)
+	assert.Equal(t, "12345678901234567890.1234567890", d.String())
+}
+
+func TestNilDecimal(t *testing.T) {
+	assert.True(t, NilDecimal().Nil())
+	assert.Equal(t, StateNull, NilDecimal().State())
+	assert.Equal(t, StateUnset, Decimal{}.State())
+	assert.False(t, NewDecimal(1, 0).Nil())
+
+	d := NewDecimal(1, 0)
+	d.Reset()
+	assert.Equal(t, Decimal{}, d)
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		give      string
+		want      string
+		wantScale int32
+		wantErr   bool
+	}{
+		{give: "123.45", want: "123.45", wantScale: 2},
+		{give: "1.50", want: "1.50", wantScale: 2},
+		{give: "-0.5", want: "-0.5", wantScale: 1},
+		{give: "+7", want: "7", wantScale: 0},
+		{give: ".5", want: "0.5", wantScale: 1},
+		{give: "5.", want: "5", wantScale: 0},
+		{give: "1e3", want: "1000", wantScale: 0},
+		{give: "1.25E-3", want: "0.00125", wantScale: 5},
+		{give: "9007199254740993.000000001", want: "9007199254740993.000000001", wantScale: 9},
+		{give: "-0.00", want: "0.00", wantScale: 2},
+		{give: "", wantErr: true},
+		{give: "-", wantErr: true},
+		{give: ".", wantErr: true},
+		{give: "1.2.3", wantErr: true},
+		{give: "abc", wantErr: true},
+		{give: "NaN", wantErr: true},
+		{give: "1e", wantErr: true},
+		{give: "1e-1000000", wantErr: true},
+		{give: "1e1000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseDecimal(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantScale, got.Scale())
+		})
+	}
+
+	assert.Panics(t, func() { MustParseDecimal("abc") })
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	d := MustParseDecimal
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"Add", d("0.1").Add(d("0.2")), "0.3"},
+		{"Add Scales", d("1.5").Add(d("2.25")), "3.75"},
+		{"Add Nil", d("1.5").Add(NilDecimal()), ""},
+		{"Sub", d("10").Sub(d("0.01")), "9.99"},
+		{"Sub Negative", d("1").Sub(d("2.50")), "-1.50"},
+		{"Mul", d("19.99").Mul(d("0.0825")), "1.649175"},
+		{"Mul Nil", NilDecimal().Mul(d("2")), ""},
+		{"Neg", d("1.50").Neg(), "-1.50"},
+		{"Neg Negative", d("-3").Neg(), "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want == "" {
+				assert.True(t, tt.got.Nil())
+				return
+			}
+			assert.Equal(t, MustParseDecimal(tt.want), tt.got)
+		})
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	d := MustParseDecimal
+	assert.Equal(t, 0, d("1.50").Cmp(d("1.5")))
+	assert.Equal(t, -1, d("1.49").Cmp(d("1.5")))
+	assert.Equal(t, 1, d("-1").Cmp(d("-1.01")))
+	assert.Equal(t, 0, NilDecimal().Cmp(d("0")))
+	assert.Equal(t, -1, d("-1").Sign())
+	assert.Equal(t, 0, d("0.00").Sign())
+	assert.Equal(t, 1.5, d("1.50").Float())
+	assert.Equal(t, big.NewRat(3, 2), d("1.50").Rat())
+}
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		name string
+		mode RoundingMode
+		want []string // for 2.5, 3.5, -2.5, 2.4, 2.6, -2.6
+	}{
+		{"HalfUp", RoundHalfUp, []string{"3", "4", "-3", "2", "3", "-3"}},
+		{"HalfEven", RoundHalfEven, []string{"2", "4", "-2", "2", "3", "-3"}},
+		{"HalfDown", RoundHalfDown, []string{"2", "3", "-2", "2", "3", "-3"}},
+		{"Up", RoundUp, []string{"3", "4", "-3", "3", "3", "-3"}},
+		{"Down", RoundDown, []string{"2", "3", "-2", "2", "2", "-2"}},
+		{"Ceiling", RoundCeiling, []string{"3", "4", "-2", "3", "3", "-2"}},
+		{"Floor", RoundFloor, []string{"2", "3", "-3", "2", "2", "-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, give := range []string{"2.5", "3.5", "-2.5", "2.4", "2.6", "-2.6"} {
+				got := MustParseDecimal(give).Round(0, tt.mode)
+				assert.Equal(t, tt.want[i], got.String(), "%s", give)
+			}
+		})
+	}
+
+	assert.Equal(t, "1.2350", MustParseDecimal("1.235").Round(4, RoundDown).String())
+	assert.Equal(t, "1.24", MustParseDecimal("1.235").Round(2, RoundHalfUp).String())
+	assert.Equal(t, "1.24", MustParseDecimal("1.245").Round(2, RoundHalfEven).String())
+	assert.Equal(t, "1200", MustParseDecimal("1234").Round(-2, RoundHalfUp).String())
+	assert.True(t, NilDecimal().Round(2, RoundHalfUp).Nil())
+}
+
+func TestDecimal_Quo(t *testing.T) {
+	d := MustParseDecimal
+	tests := []struct {
+		name    string
+		x, y    Decimal
+		scale   int32
+		mode    RoundingMode
+		want    string
+		wantErr bool
+	}{
+		{"Exact", d("10"), d("4"), 2, RoundHalfUp, "2.50", false},
+		{"Third", d("1"), d("3"), 4, RoundHalfUp, "0.3333", false},
+		{"Two Thirds Down", d("2"), d("3"), 2, RoundDown, "0.66", false},
+		{"Two Thirds HalfUp", d("2"), d("3"), 2, RoundHalfUp, "0.67", false},
+		{"Negative", d("-1"), d("8"), 2, RoundHalfEven, "-0.12", false},
+		{"Scaled Divisor", d("100"), d("0.25"), 0, RoundHalfUp, "400", false},
+		{"Scaled Dividend", d("1.2345"), d("10"), 2, RoundHalfUp, "0.12", false},
+		{"Zero", d("1"), d("0.00"), 2, RoundHalfUp, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.x.Quo(tt.y, tt.scale, tt.mode)
+			assertWantError(t, tt.wantErr, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+
+	got, err := NilDecimal().Quo(d("0"), 2, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.True(t, got.Nil())
+}
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Decimal
+		wantErr bool
+	}{
+		{"String", `"123.45"`, NewDecimal(12345, 2), false},
+		{"Trailing Zeros", `"1.50"`, NewDecimal(150, 2), false},
+		{"Number", `123.45`, NewDecimal(12345, 2), false},
+		{"Large Number", `12345678901234567890.01`, MustParseDecimal("12345678901234567890.01"), false},
+		{"Null", `null`, NilDecimal(), false},
+		{"Invalid String", `"abc"`, Decimal{}, true},
+		{"Boolean", `true`, Decimal{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Decimal
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Decimal
+		want string
+	}{
+		{"Uninitialized", Decimal{}, `null`},
+		{"Nil", NilDecimal(), `null`},
+		{"Value", NewDecimal(150, 2), `"1.50"`},
+		{"Negative", NewDecimal(-5, 3), `"-0.005"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestDecimal_JSONRoundTrip(t *testing.T) {
+	type invoice struct {
+		Price Decimal `json:"price"`
+		Tax   Decimal `json:"tax"`
+		Fee   Decimal `json:"fee"`
+	}
+	give := invoice{Price: MustParseDecimal("1999999999999999.99"), Tax: NilDecimal()}
+
+	b, err := json.Marshal(give)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":"1999999999999999.99","tax":null,"fee":null}`, string(b))
+
+	var got invoice
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":"1999999999999999.99","tax":null}`), &got))
+	assert.Equal(t, give, got)
+}
+
+func TestDecimal_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Decimal
+		want driver.Value
+	}{
+		{"Nil", NilDecimal(), nil},
+		{"Value", NewDecimal(150, 2), "1.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Decimal
+		wantErr bool
+	}{
+		{"Nil", nil, NilDecimal(), false},
+		{"String", "123.45", NewDecimal(12345, 2), false},
+		{"Byte Slice", []byte("0.0825"), NewDecimal(825, 4), false},
+		{"Int", int64(42), NewDecimal(42, 0), false},
+		{"Float", 1.5, NewDecimal(15, 1), false},
+		{"NaN", "NaN", Decimal{}, true},
+		{"Bool", true, Decimal{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Decimal
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal_ScanNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    pgtype.Numeric
+		want    Decimal
+		wantErr bool
+	}{
+		{"Invalid", pgtype.Numeric{}, NilDecimal(), false},
+		{"Value", pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true}, NewDecimal(12345, 2), false},
+		{"Positive Exponent", pgtype.Numeric{Int: big.NewInt(12), Exp: 2, Valid: true}, NewDecimal(1200, 0), false},
+		{"Nil Int", pgtype.Numeric{Exp: -2, Valid: true}, NewDecimal(0, 2), false},
+		{"NaN", pgtype.Numeric{NaN: true, Valid: true}, Decimal{}, true},
+		{"Infinity", pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, Decimal{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Decimal
+			err := got.ScanNumeric(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal_NumericValue(t *testing.T) {
+	got, err := NewDecimal(-12345, 2).NumericValue()
+	assert.NoError(t, err)
+	assert.Equal(t, pgtype.Numeric{Int: big.NewInt(-12345), Exp: -2, Valid: true}, got)
+
+	got, err = NilDecimal().NumericValue()
+	assert.NoError(t, err)
+	assert.False(t, got.Valid)
+}
+
+func TestRoundingMode_String(t *testing.T) {
+	assert.Equal(t, "RoundHalfEven", RoundHalfEven.String())
+	assert.Equal(t, "RoundFloor", RoundFloor.String())
+	assert.Equal(t, "RoundingMode(9)", RoundingMode(9).String())
+}
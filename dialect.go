@@ -0,0 +1,119 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"strconv"
+	"sync/atomic"
+)
+
+// Dialect selects how Bool, Date, Time and UUID are scanned from and valued
+// for a particular database through database/sql. The other types behave the
+// same for every dialect, as does scanning and valuing through pgx.
+//
+//   - Postgres: the default; see the documentation of each type
+//   - MySQL: Bools scan from TINYINT(1) text such as "1", and value as 0 or 1
+//   - SQLite: as MySQL, and Times are valued as text since SQLite has no
+//     timestamp type
+//   - SQLServer: UUIDs scan from and value as UNIQUEIDENTIFIER bytes, whose
+//     first three groups are little-endian
+//
+// Dialects other than Postgres have no infinite dates or times, so valuing
+// one is an error.
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLite
+	SQLServer
+)
+
+var defaultDialect atomic.Int32
+
+// SetDialect sets the dialect used by the Scan and Value methods of this
+// package's types. Use Dialect.Scanner and Dialect.Valuer instead to talk to
+// databases of different dialects from the same process.
+func SetDialect(d Dialect) {
+	defaultDialect.Store(int32(d))
+}
+
+// CurrentDialect returns the dialect set with SetDialect
+func CurrentDialect() Dialect {
+	return Dialect(defaultDialect.Load())
+}
+
+// String implements the fmt.Stringer interface
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "Postgres"
+	case MySQL:
+		return "MySQL"
+	case SQLite:
+		return "SQLite"
+	case SQLServer:
+		return "SQLServer"
+	}
+	return "Dialect(" + strconv.Itoa(int(d)) + ")"
+}
+
+type dialectScanner interface {
+	scan(d Dialect, src any) error
+}
+
+type dialectValuer interface {
+	value(d Dialect) (driver.Value, error)
+}
+
+type dialectScanFunc func(src any) error
+
+func (f dialectScanFunc) Scan(src any) error {
+	return f(src)
+}
+
+type dialectValueFunc func() (driver.Value, error)
+
+func (f dialectValueFunc) Value() (driver.Value, error) {
+	return f()
+}
+
+// Scanner returns a sql.Scanner that scans into dst using this dialect
+// regardless of the one set with SetDialect, e.g.
+//
+//	row.Scan(nillabletypes.SQLServer.Scanner(&id))
+//
+// dst must be a pointer to one of this package's types or another sql.Scanner.
+func (d Dialect) Scanner(dst sql.Scanner) sql.Scanner {
+	if s, ok := dst.(dialectScanner); ok {
+		return dialectScanFunc(func(src any) error {
+			return s.scan(d, src)
+		})
+	}
+	return dst
+}
+
+// Valuer returns a driver.Valuer that values v using this dialect regardless
+// of the one set with SetDialect
+func (d Dialect) Valuer(v driver.Valuer) driver.Valuer {
+	if dv, ok := v.(dialectValuer); ok {
+		return dialectValueFunc(func() (driver.Value, error) {
+			return dv.value(d)
+		})
+	}
+	return v
+}
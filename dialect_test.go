@@ -0,0 +1,159 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubMixedUUID has distinct bytes so that stubMixedUUIDBytes, its SQL Server
+// uniqueidentifier encoding, shows the byte swap
+var (
+	stubMixedUUID      = StringsToUUIDs([]string{"00112233-4455-6677-8899-aabbccddeeff"})[0]
+	stubMixedUUIDBytes = []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+)
+
+func TestSetDialect(t *testing.T) {
+	defer SetDialect(CurrentDialect())
+
+	assert.Equal(t, Postgres, CurrentDialect())
+	SetDialect(MySQL)
+	assert.Equal(t, MySQL, CurrentDialect())
+
+	got, err := NewBool(true).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), got)
+
+	var b Bool
+	assert.NoError(t, b.Scan([]byte("0")))
+	assert.Equal(t, NewBool(false), b)
+}
+
+func TestDialect_String(t *testing.T) {
+	assert.Equal(t, "Postgres", Postgres.String())
+	assert.Equal(t, "MySQL", MySQL.String())
+	assert.Equal(t, "SQLite", SQLite.String())
+	assert.Equal(t, "SQLServer", SQLServer.String())
+	assert.Equal(t, "Dialect(9)", Dialect(9).String())
+}
+
+func TestDialect_Valuer(t *testing.T) {
+	stubTime := time.Date(2019, 11, 12, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		dialect Dialect
+		give    driver.Valuer
+		want    driver.Value
+		wantErr bool
+	}{
+		{"Postgres Bool", Postgres, NewBool(true), true, false},
+		{"MySQL Bool", MySQL, NewBool(true), int64(1), false},
+		{"SQLite Bool", SQLite, NewBool(false), int64(0), false},
+		{"SQLite Nil Bool", SQLite, NilBool(), nil, false},
+		{"SQLServer Bool", SQLServer, NewBool(true), true, false},
+		{"Postgres Date", Postgres, NewDate("2019-11-12"), "2019-11-12", false},
+		{"Postgres Infinite Date", Postgres, NewInfiniteDate(), "infinity", false},
+		{"MySQL Infinite Date", MySQL, NewInfiniteDate(), nil, true},
+		{"Postgres Time", Postgres, NewTime(stubTime), stubTime, false},
+		{"SQLite Time", SQLite, NewTime(stubTime), "2019-11-12 10:30:00+00:00", false},
+		{"SQLite Nil Time", SQLite, NilTime(), nil, false},
+		{"SQLServer Infinite Time", SQLServer, NewNegInfiniteTime(), nil, true},
+		{"Postgres UUID", Postgres, NewUUID(stubMixedUUID), "00112233-4455-6677-8899-aabbccddeeff", false},
+		{"SQLServer UUID", SQLServer, NewUUID(stubMixedUUID), stubMixedUUIDBytes, false},
+		{"SQLServer Nil UUID", SQLServer, NilUUID(), nil, false},
+		{"Other Type", SQLite, NewInt64(1), int64(1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.dialect.Valuer(tt.give).Value()
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDialect_Scanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		give    any
+		dst     sql.Scanner
+		want    any
+		wantErr bool
+	}{
+		{"Postgres Bool Text", Postgres, []byte("1"), &Bool{}, Bool{}, true},
+		{"MySQL Bool Text", MySQL, []byte("1"), &Bool{}, NewBool(true), false},
+		{"SQLite Bool Text", SQLite, "false", &Bool{}, NewBool(false), false},
+		{"MySQL Bool Invalid", MySQL, "yes", &Bool{}, Bool{}, true},
+		{"Postgres Date Text", Postgres, "2019-11-12", &Date{}, NewDate("2019-11-12"), false},
+		{"MySQL Date Text", MySQL, []byte("2019-11-12"), &Date{}, NewDate("2019-11-12"), false},
+		{"SQLite Date Text", SQLite, "2019-11-12 10:30:00", &Date{}, NewDate("2019-11-12"), false},
+		{"SQLite Invalid Date Text", SQLite, "2019-02-30", &Date{}, Date{}, true},
+		{"Postgres Time Text", Postgres, "2019-11-12 10:30:00+00", &Time{}, NewTime(time.Date(2019, 11, 12, 10, 30, 0, 0, time.UTC)), false},
+		{"MySQL Time Text", MySQL, []byte("2019-11-12 10:30:00.5"), &Time{}, NewTime(time.Date(2019, 11, 12, 10, 30, 0, 5e8, time.UTC)), false},
+		{"SQLite Time Text", SQLite, "2019-11-12T10:30:00-07:00", &Time{}, NewTime(time.Date(2019, 11, 12, 10, 30, 0, 0, time.FixedZone("", -7*60*60))), false},
+		{"SQLite Time Invalid", SQLite, "noon", &Time{}, Time{}, true},
+		{"Postgres UUID Bytes", Postgres, stubMixedUUIDBytes, &UUID{}, NewUUID(StringsToUUIDs([]string{"33221100-5544-7766-8899-aabbccddeeff"})[0]), false},
+		{"SQLServer UUID Bytes", SQLServer, stubMixedUUIDBytes, &UUID{}, NewUUID(stubMixedUUID), false},
+		{"SQLServer UUID Text", SQLServer, []byte("00112233-4455-6677-8899-aabbccddeeff"), &UUID{}, NewUUID(stubMixedUUID), false},
+		{"Other Type", SQLite, "12", &Int64{}, NewInt64(12), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dialect.Scanner(tt.dst).Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			got := reflect.ValueOf(tt.dst).Elem().Interface()
+			if want, ok := tt.want.(Time); ok {
+				assert.True(t, want.Time().Equal(got.(Time).Time()))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDialect_RoundTrip(t *testing.T) {
+	stubTime := time.Date(2019, 11, 12, 10, 30, 0, 0, time.UTC)
+	for _, d := range []Dialect{Postgres, MySQL, SQLite, SQLServer} {
+		t.Run(d.String(), func(t *testing.T) {
+			for _, give := range []any{NewBool(true), NewUUID(stubMixedUUID), NewTime(stubTime)} {
+				v, err := d.Valuer(give.(driver.Valuer)).Value()
+				assert.NoError(t, err)
+				assert.True(t, driver.IsValue(v))
+
+				switch give := give.(type) {
+				case Bool:
+					var got Bool
+					assert.NoError(t, d.Scanner(&got).Scan(v))
+					assert.Equal(t, give, got)
+				case UUID:
+					var got UUID
+					assert.NoError(t, d.Scanner(&got).Scan(v))
+					assert.Equal(t, give, got)
+				case Time:
+					var got Time
+					assert.NoError(t, d.Scanner(&got).Scan(v))
+					assert.True(t, give.Time().Equal(got.Time()))
+				}
+			}
+		})
+	}
+}
@@ -17,27 +17,66 @@ package nillabletypes
 import (
 	"bytes"
 	"database/sql/driver"
+	"math"
 	"strconv"
+	"sync/atomic"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/segmentio/encoding/json"
 )
 
-// Float represents a nil-able float
-type Float struct {
-	v           float64
-	present     bool
-	initialized bool
+// NonFinitePolicy selects how Floats that are NaN or ±Inf, which JSON can't
+// represent, are marshaled
+type NonFinitePolicy int
+
+const (
+	// NonFiniteError makes MarshalJSON return an error. This is the default.
+	NonFiniteError NonFinitePolicy = iota
+	// NonFiniteNull marshals non-finite Floats as null
+	NonFiniteNull
+	// NonFiniteString marshals non-finite Floats as the strings "NaN",
+	// "Infinity" and "-Infinity", and unmarshals those strings back
+	NonFiniteString
+)
+
+var nonFinitePolicy atomic.Int32
+
+// SetNonFinitePolicy sets how Floats that are NaN or ±Inf are marshaled to and
+// unmarshaled from JSON
+func SetNonFinitePolicy(p NonFinitePolicy) {
+	nonFinitePolicy.Store(int32(p))
 }
 
+// CurrentNonFinitePolicy returns the policy set with SetNonFinitePolicy
+func CurrentNonFinitePolicy() NonFinitePolicy {
+	return NonFinitePolicy(nonFinitePolicy.Load())
+}
+
+// String implements the fmt.Stringer interface
+func (p NonFinitePolicy) String() string {
+	switch p {
+	case NonFiniteError:
+		return "NonFiniteError"
+	case NonFiniteNull:
+		return "NonFiniteNull"
+	case NonFiniteString:
+		return "NonFiniteString"
+	}
+	return "NonFinitePolicy(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Float represents a nil-able float
+type Float Nillable[float64]
+
 // NewFloat makes a new non-nil Float
 func NewFloat(v float64) Float {
-	return Float{v: v, present: true, initialized: true}
+	return Float(New(v))
 }
 
 // NilFloat makes a new nil Float
 func NilFloat() Float {
-	return Float{0, false, true}
+	return Float(Nil[float64]())
 }
 
 // Float returns the built-in float64 value
@@ -45,9 +84,36 @@ func (v Float) Float() float64 {
 	return v.v
 }
 
+// Finite returns whether this Float is a usable number, i.e. non-nil and
+// neither NaN nor ±Inf
+func (v Float) Finite() bool {
+	return v.present && !math.IsNaN(v.v) && !math.IsInf(v.v, 0)
+}
+
 // Nil returns whether this scalar is nil
 func (v Float) Nil() bool {
-	return !v.present
+	return Nillable[float64](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Float) Initialized() bool {
+	return Nillable[float64](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Float) Set() bool {
+	return Nillable[float64](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Float) State() State {
+	return Nillable[float64](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Float) Reset() {
+	(*Nillable[float64])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -55,36 +121,51 @@ func (v Float) String() string {
 	return strconv.FormatFloat(v.v, 'f', -1, 64)
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface
+// UnmarshalJSON implements the json.Unmarshaler interface. Under the
+// NonFiniteString policy it also accepts "NaN", "Infinity" and "-Infinity".
 func (v *Float) UnmarshalJSON(data []byte) error {
-	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
-		v.present = false
-		v.initialized = true
+	if len(data) > 0 && data[0] == '"' && CurrentNonFinitePolicy() == NonFiniteString {
+		var f float64
+		switch {
+		case bytes.Equal(data, []byte(`"NaN"`)):
+			f = math.NaN()
+		case bytes.Equal(data, []byte(`"Infinity"`)):
+			f = math.Inf(1)
+		case bytes.Equal(data, []byte(`"-Infinity"`)):
+			f = math.Inf(-1)
+		default:
+			return errors.Errorf("cannot unmarshal %s to a float", data)
+		}
+		*v = Float{v: f, present: true, initialized: true}
 		return nil
 	}
-	err := json.Unmarshal(data, &v.v)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	v.present = true
-	v.initialized = true
-	return nil
+	return (*Nillable[float64])(v).UnmarshalJSON(data)
 }
 
-// MarshalJSON implements the json.Marshaler interface
+// MarshalJSON implements the json.Marshaler interface. NaN and ±Inf are
+// marshaled according to the current NonFinitePolicy.
 func (v Float) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
+	if v.initialized && v.present && !v.Finite() {
+		switch CurrentNonFinitePolicy() {
+		case NonFiniteNull:
+			return []byte{'n', 'u', 'l', 'l'}, nil
+		case NonFiniteString:
+			switch {
+			case math.IsNaN(v.v):
+				return json.Marshal("NaN")
+			case v.v > 0:
+				return json.Marshal("Infinity")
+			}
+			return json.Marshal("-Infinity")
+		}
+		return nil, errors.Errorf("cannot marshal non-finite float %v to JSON", v.v)
 	}
-	return json.Marshal(v.v)
+	return Nillable[float64](v).MarshalJSON()
 }
 
 // Value implements the driver.Valuer interface
 func (v Float) Value() (driver.Value, error) {
-	if !v.present {
-		return nil, nil
-	}
-	return v.v, nil
+	return Nillable[float64](v).Value()
 }
 
 // Scan implements the sql.Scanner interface
@@ -126,3 +207,14 @@ func (v *Float) scanString(src string) error {
 	*v = Float{v: f, present: true, initialized: true}
 	return nil
 }
+
+// ScanFloat64 implements the pgtype.Float64Scanner interface
+func (v *Float) ScanFloat64(src pgtype.Float8) error { //nolint:unparam
+	*v = Float{v: src.Float64, present: src.Valid, initialized: true}
+	return nil
+}
+
+// Float64Value implements the pgtype.Float64Valuer interface
+func (v Float) Float64Value() (pgtype.Float8, error) { //nolint:unparam
+	return pgtype.Float8{Float64: v.v, Valid: v.present}, nil
+}
@@ -17,11 +17,13 @@ package nillabletypes
 import (
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewFloat(t *testing.T) {
@@ -385,3 +387,113 @@ func TestFloat_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestFloat_Finite(t *testing.T) {
+	assert.True(t, NewFloat(0).Finite())
+	assert.True(t, NewFloat(-1.5).Finite())
+	assert.False(t, NewFloat(math.NaN()).Finite())
+	assert.False(t, NewFloat(math.Inf(1)).Finite())
+	assert.False(t, NewFloat(math.Inf(-1)).Finite())
+	assert.False(t, NilFloat().Finite())
+	assert.False(t, Float{}.Finite())
+}
+
+func TestNonFinitePolicy_MarshalJSON(t *testing.T) {
+	defer SetNonFinitePolicy(CurrentNonFinitePolicy())
+
+	tests := []struct {
+		name    string
+		policy  NonFinitePolicy
+		give    Float
+		want    string
+		wantErr bool
+	}{
+		{"Error NaN", NonFiniteError, NewFloat(math.NaN()), "", true},
+		{"Error Inf", NonFiniteError, NewFloat(math.Inf(1)), "", true},
+		{"Error Finite", NonFiniteError, NewFloat(1.5), `1.5`, false},
+		{"Null NaN", NonFiniteNull, NewFloat(math.NaN()), `null`, false},
+		{"Null -Inf", NonFiniteNull, NewFloat(math.Inf(-1)), `null`, false},
+		{"String NaN", NonFiniteString, NewFloat(math.NaN()), `"NaN"`, false},
+		{"String Inf", NonFiniteString, NewFloat(math.Inf(1)), `"Infinity"`, false},
+		{"String -Inf", NonFiniteString, NewFloat(math.Inf(-1)), `"-Infinity"`, false},
+		{"String Nil", NonFiniteString, NilFloat(), `null`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetNonFinitePolicy(tt.policy)
+			got, err := tt.give.MarshalJSON()
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestNonFinitePolicy_UnmarshalJSON(t *testing.T) {
+	defer SetNonFinitePolicy(CurrentNonFinitePolicy())
+
+	tests := []struct {
+		name    string
+		policy  NonFinitePolicy
+		give    string
+		want    float64
+		wantErr bool
+	}{
+		{"Error NaN", NonFiniteError, `"NaN"`, 0, true},
+		{"Null NaN", NonFiniteNull, `"NaN"`, 0, true},
+		{"String NaN", NonFiniteString, `"NaN"`, math.NaN(), false},
+		{"String Inf", NonFiniteString, `"Infinity"`, math.Inf(1), false},
+		{"String -Inf", NonFiniteString, `"-Infinity"`, math.Inf(-1), false},
+		{"String Other", NonFiniteString, `"1.5"`, 0, true},
+		{"String Number", NonFiniteString, `1.5`, 1.5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetNonFinitePolicy(tt.policy)
+			var got Float
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			if tt.wantErr {
+				return
+			}
+			if math.IsNaN(tt.want) {
+				assert.True(t, math.IsNaN(got.Float()))
+			} else {
+				assert.Equal(t, tt.want, got.Float())
+			}
+		})
+	}
+}
+
+func TestNonFinitePolicy_Struct(t *testing.T) {
+	defer SetNonFinitePolicy(CurrentNonFinitePolicy())
+
+	type valuation struct {
+		Estimate Float `json:"estimate"`
+		Ratio    Float `json:"ratio"`
+	}
+	give := valuation{Estimate: NewFloat(250000), Ratio: NewFloat(math.Inf(1))}
+
+	_, err := json.Marshal(give)
+	assert.Error(t, err)
+
+	SetNonFinitePolicy(NonFiniteNull)
+	b, err := json.Marshal(give)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"estimate":250000,"ratio":null}`, string(b))
+
+	SetNonFinitePolicy(NonFiniteString)
+	b, err = json.Marshal(give)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"estimate":250000,"ratio":"Infinity"}`, string(b))
+
+	var got valuation
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, give, got)
+}
+
+func TestNonFinitePolicy_String(t *testing.T) {
+	assert.Equal(t, "NonFiniteError", NonFiniteError.String())
+	assert.Equal(t, "NonFiniteNull", NonFiniteNull.String())
+	assert.Equal(t, "NonFiniteString", NonFiniteString.String())
+	assert.Equal(t, "NonFinitePolicy(5)", NonFinitePolicy(5).String())
+}
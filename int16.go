@@ -0,0 +1,193 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"math"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// Int16 represents a nil-able int16, such as a PostgreSQL smallint
+type Int16 Nillable[int16]
+
+// NewInt16 makes a new non-nil Int16
+func NewInt16(v int16) Int16 {
+	return Int16(New(v))
+}
+
+// NilInt16 makes a new nil Int16
+func NilInt16() Int16 {
+	return Int16(Nil[int16]())
+}
+
+// Int16 returns the built-in int16 value
+func (v Int16) Int16() int16 {
+	return v.v
+}
+
+// Nil returns whether this scalar is nil
+func (v Int16) Nil() bool {
+	return Nillable[int16](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Int16) Initialized() bool {
+	return Nillable[int16](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Int16) Set() bool {
+	return Nillable[int16](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Int16) State() State {
+	return Nillable[int16](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Int16) Reset() {
+	(*Nillable[int16])(v).Reset()
+}
+
+// String implements the fmt.Stringer interface
+func (v Int16) String() string {
+	return strconv.FormatInt(int64(v.v), 10)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *Int16) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		v.present = false
+		v.initialized = true
+		return nil
+	}
+	n, err := jsonToInt64(data)
+	if err != nil {
+		return err
+	}
+	i, err := int64ToInt16(n)
+	if err != nil {
+		return err
+	}
+	v.v = i
+	v.present = true
+	v.initialized = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Int16) MarshalJSON() ([]byte, error) {
+	return Nillable[int16](v).MarshalJSON()
+}
+
+// Value implements the driver.Valuer interface, returning the value as an int64
+func (v Int16) Value() (driver.Value, error) { //nolint:unparam
+	if !v.present {
+		return nil, nil
+	}
+	return int64(v.v), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Int16) Scan(src interface{}) error {
+	if src == nil {
+		*v = Int16{present: false, initialized: true}
+		return nil
+	}
+	switch t := src.(type) {
+	case int64:
+		i, err := int64ToInt16(t)
+		if err != nil {
+			return err
+		}
+		*v = Int16{v: i, present: true, initialized: true}
+		return nil
+	case float64:
+		val, err := float64ToInt16(t)
+		if err != nil {
+			return err
+		}
+		*v = Int16{v: val, present: true, initialized: true}
+		return nil
+	case bool:
+		if t {
+			*v = Int16{v: 1, present: true, initialized: true}
+		} else {
+			*v = Int16{v: 0, present: true, initialized: true}
+		}
+		return nil
+	case []byte:
+		s := string(t)
+		return v.scanString(s)
+	case string:
+		return v.scanString(t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to an int", src)
+}
+
+func (v *Int16) scanString(src string) error {
+	f, err := strconv.ParseFloat(src, 64)
+	if err != nil {
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to an int", src)
+	}
+	i, err := float64ToInt16(f)
+	if err != nil {
+		return err
+	}
+
+	*v = Int16{v: i, present: true, initialized: true}
+	return nil
+}
+
+// ScanInt64 implements the pgtype.Int64Scanner interface
+func (v *Int16) ScanInt64(src pgtype.Int8) error {
+	if !src.Valid {
+		*v = Int16{present: false, initialized: true}
+		return nil
+	}
+	i, err := int64ToInt16(src.Int64)
+	if err != nil {
+		return err
+	}
+	*v = Int16{v: i, present: true, initialized: true}
+	return nil
+}
+
+// Int64Value implements the pgtype.Int64Valuer interface
+func (v Int16) Int64Value() (pgtype.Int8, error) { //nolint:unparam
+	return pgtype.Int8{Int64: int64(v.v), Valid: v.present}, nil
+}
+
+func int64ToInt16(i int64) (int16, error) {
+	if math.MaxInt16 < i || math.MinInt16 > i {
+		return 0, errors.Errorf("value %v outside of the range of int16", i)
+	}
+	return int16(i), nil
+}
+
+func float64ToInt16(f float64) (int16, error) {
+	val := int16(f)
+	if math.Trunc(f) != float64(val) {
+		return 0, errors.Errorf("value %f outside of the range of int16", f)
+	}
+	return val, nil
+}
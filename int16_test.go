@@ -0,0 +1,134 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInt16(t *testing.T) {
+	assert.Equal(t, Int16{v: math.MaxInt16, present: true, initialized: true}, NewInt16(math.MaxInt16))
+	assert.Equal(t, Int16{present: false, initialized: true}, NilInt16())
+	assert.Equal(t, int16(math.MaxInt16), NewInt16(math.MaxInt16).Int16())
+	assert.True(t, NilInt16().Nil())
+	assert.Equal(t, StateValue, NewInt16(0).State())
+
+	v := NewInt16(1)
+	v.Reset()
+	assert.Equal(t, Int16{}, v)
+}
+
+func TestInt16_String(t *testing.T) {
+	assert.Equal(t, "32767", NewInt16(math.MaxInt16).String())
+	assert.Equal(t, "-32768", NewInt16(math.MinInt16).String())
+	assert.Equal(t, "0", NilInt16().String())
+}
+
+func TestInt16_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Int16
+		wantErr bool
+	}{
+		{"Max", `32767`, NewInt16(math.MaxInt16), false},
+		{"Min", `-32768`, NewInt16(math.MinInt16), false},
+		{"Whole Floating Point Number", `3.0`, NewInt16(3), false},
+		{"Null", `null`, NilInt16(), false},
+		{"Above Max", `32768`, Int16{}, true},
+		{"Below Min", `-32769`, Int16{}, true},
+		{"Fraction", `3.5`, Int16{}, true},
+		{"String", `"3"`, Int16{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Int16
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInt16_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Int16
+		want string
+	}{
+		{"Value", NewInt16(math.MaxInt16), `32767`},
+		{"Nil", NilInt16(), `null`},
+		{"Uninitialized", Int16{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestInt16_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Int16
+		want driver.Value
+	}{
+		{"Nil", NilInt16(), nil},
+		{"Max", NewInt16(math.MaxInt16), int64(math.MaxInt16)},
+		{"Min", NewInt16(math.MinInt16), int64(math.MinInt16)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInt16_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Int16
+		wantErr bool
+	}{
+		{"Nil", nil, NilInt16(), false},
+		{"True", true, NewInt16(1), false},
+		{"Int Max", int64(math.MaxInt16), NewInt16(math.MaxInt16), false},
+		{"Int Above Max", int64(32768), Int16{}, true},
+		{"Int Below Min", int64(-32769), Int16{}, true},
+		{"Float", 12.0, NewInt16(12), false},
+		{"Float Above Max", 32768.0, Int16{}, true},
+		{"Byte Slice", []byte("32767"), NewInt16(math.MaxInt16), false},
+		{"String", "-32768", NewInt16(math.MinInt16), false},
+		{"String Above Max", "32768", Int16{}, true},
+		{"Word", "twister", Int16{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Int16
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -20,25 +20,21 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
-	"github.com/segmentio/encoding/json"
 )
 
 // Int32 represents a nil-able int
-type Int32 struct {
-	v           int32
-	present     bool
-	initialized bool
-}
+type Int32 Nillable[int32]
 
 // NewInt32 makes a new non-nil Int
 func NewInt32(v int32) Int32 {
-	return Int32{v: v, present: true, initialized: true}
+	return Int32(New(v))
 }
 
 // NilInt32 makes a new nil Int
 func NilInt32() Int32 {
-	return Int32{0, false, true}
+	return Int32(Nil[int32]())
 }
 
 // Int32 returns the built-in int32 value
@@ -48,7 +44,28 @@ func (v Int32) Int32() int32 {
 
 // Nil returns whether this scalar is nil
 func (v Int32) Nil() bool {
-	return !v.present
+	return Nillable[int32](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Int32) Initialized() bool {
+	return Nillable[int32](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Int32) Set() bool {
+	return Nillable[int32](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Int32) State() State {
+	return Nillable[int32](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Int32) Reset() {
+	(*Nillable[int32])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -63,13 +80,11 @@ func (v *Int32) UnmarshalJSON(data []byte) error {
 		v.initialized = true
 		return nil
 	}
-	var t float64
-	err := json.Unmarshal(data, &t)
+	n, err := jsonToInt64(data)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
-
-	i, err := float64ToInt32(t)
+	i, err := int64ToInt32(n)
 	if err != nil {
 		return err
 	}
@@ -81,18 +96,15 @@ func (v *Int32) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON implements the json.Marshaler interface
 func (v Int32) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
-	}
-	return json.Marshal(v.v)
+	return Nillable[int32](v).MarshalJSON()
 }
 
-// Value implements the driver.Valuer interface
-func (v Int32) Value() (driver.Value, error) {
+// Value implements the driver.Valuer interface, returning the value as an int64
+func (v Int32) Value() (driver.Value, error) { //nolint:unparam
 	if !v.present {
 		return nil, nil
 	}
-	return v.v, nil
+	return int64(v.v), nil
 }
 
 // Scan implements the sql.Scanner interface
@@ -146,6 +158,25 @@ func (v *Int32) scanString(src string) error {
 	return nil
 }
 
+// ScanInt64 implements the pgtype.Int64Scanner interface
+func (v *Int32) ScanInt64(src pgtype.Int8) error {
+	if !src.Valid {
+		*v = Int32{present: false, initialized: true}
+		return nil
+	}
+	i, err := int64ToInt32(src.Int64)
+	if err != nil {
+		return err
+	}
+	*v = Int32{v: i, present: true, initialized: true}
+	return nil
+}
+
+// Int64Value implements the pgtype.Int64Valuer interface
+func (v Int32) Int64Value() (pgtype.Int8, error) { //nolint:unparam
+	return pgtype.Int8{Int64: int64(v.v), Valid: v.present}, nil
+}
+
 func int64ToInt32(i int64) (int32, error) {
 	if math.MaxInt32 < i || math.MinInt32 > i {
 		return 0, errors.Errorf("value %v outside of the range of int32", i)
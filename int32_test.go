@@ -16,6 +16,7 @@ package nillabletypes
 
 import (
 	"database/sql/driver"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -181,9 +182,36 @@ func TestInt32_UnmarshalJSON(t *testing.T) {
 		{
 			name:    "Floating Point Number",
 			give:    toBytes(3.14159),
+			wantErr: true,
+		},
+		{
+			name:    "Whole Floating Point Number",
+			give:    []byte("3.0"),
 			want:    Int32{v: 3, present: true, initialized: true},
 			wantErr: false,
 		},
+		{
+			name:    "Max",
+			give:    []byte("2147483647"),
+			want:    Int32{v: math.MaxInt32, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Min",
+			give:    []byte("-2147483648"),
+			want:    Int32{v: math.MinInt32, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Above Max",
+			give:    []byte("2147483648"),
+			wantErr: true,
+		},
+		{
+			name:    "Below Min",
+			give:    []byte("-2147483649"),
+			wantErr: true,
+		},
 		{
 			name:    "Floating Point Number (Out of Range)",
 			give:    toBytes(9999999999999999999.0),
@@ -277,7 +305,7 @@ func TestInt32_Value(t *testing.T) {
 		{
 			name:    "Not Nil",
 			give:    Int32{v: 65, present: true, initialized: true},
-			want:    int32(65),
+			want:    int64(65),
 			wantErr: false,
 		},
 	}
@@ -18,27 +18,26 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/segmentio/encoding/json"
 )
 
 // Int64 represents a nil-able int
-type Int64 struct {
-	v           int64
-	present     bool
-	initialized bool
-}
+type Int64 Nillable[int64]
 
 // NewInt64 makes a new non-nil Int
 func NewInt64(v int64) Int64 {
-	return Int64{v: v, present: true, initialized: true}
+	return Int64(New(v))
 }
 
 // NilInt64 makes a new nil Int
 func NilInt64() Int64 {
-	return Int64{0, false, true}
+	return Int64(Nil[int64]())
 }
 
 // Int64 returns the built-in int64 value
@@ -48,7 +47,28 @@ func (v Int64) Int64() int64 {
 
 // Nil returns whether this scalar is nil
 func (v Int64) Nil() bool {
-	return !v.present
+	return Nillable[int64](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Int64) Initialized() bool {
+	return Nillable[int64](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Int64) Set() bool {
+	return Nillable[int64](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Int64) State() State {
+	return Nillable[int64](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Int64) Reset() {
+	(*Nillable[int64])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -63,13 +83,7 @@ func (v *Int64) UnmarshalJSON(data []byte) error {
 		v.initialized = true
 		return nil
 	}
-	var t float64
-	err := json.Unmarshal(data, &t)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-
-	i, err := float64ToInt64(t)
+	i, err := jsonToInt64(data)
 	if err != nil {
 		return err
 	}
@@ -81,18 +95,12 @@ func (v *Int64) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON implements the json.Marshaler interface
 func (v Int64) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
-	}
-	return json.Marshal(v.v)
+	return Nillable[int64](v).MarshalJSON()
 }
 
 // Value implements the driver.Valuer interface
 func (v Int64) Value() (driver.Value, error) {
-	if !v.present {
-		return nil, nil
-	}
-	return v.v, nil
+	return Nillable[int64](v).Value()
 }
 
 // Scan implements the sql.Scanner interface
@@ -143,6 +151,80 @@ func (v *Int64) scanString(src string) error {
 	return nil
 }
 
+// ScanInt64 implements the pgtype.Int64Scanner interface
+func (v *Int64) ScanInt64(src pgtype.Int8) error { //nolint:unparam
+	*v = Int64{v: src.Int64, present: src.Valid, initialized: true}
+	return nil
+}
+
+// Int64Value implements the pgtype.Int64Valuer interface
+func (v Int64) Int64Value() (pgtype.Int8, error) { //nolint:unparam
+	return pgtype.Int8{Int64: v.v, Valid: v.present}, nil
+}
+
+// jsonToInt64 decodes a JSON number to an int64 without going through a
+// float64, so integers beyond 2^53 keep their precision. Numbers with a
+// fraction or an exponent, e.g. 3.0 or 1e3, are accepted if they are whole.
+func jsonToInt64(data []byte) (int64, error) {
+	i, s, err := jsonToBigInt(data)
+	if err != nil {
+		return 0, err
+	}
+	if !i.IsInt64() {
+		return 0, errors.Errorf("value %s outside of the range of int64", s)
+	}
+	return i.Int64(), nil
+}
+
+// jsonToBigInt decodes a whole JSON number exactly. It also returns the
+// number as written, for error messages. Numbers far outside the range of a
+// 64-bit integer are rejected.
+func jsonToBigInt(data []byte) (*big.Int, string, error) {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	s := string(n)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return big.NewInt(i), s, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.Abs(f) > math.MaxUint64 {
+		return nil, s, errors.Errorf("value %s outside of the range of a 64-bit integer", s)
+	}
+	if i, ok := new(big.Int).SetString(s, 10); ok {
+		return i, s, nil
+	}
+	// big.Rat expands the exponent, so fall back to the float64 for the
+	// extreme exponents where that would be expensive
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+		if exp, err := strconv.Atoi(s[e+1:]); err != nil || exp < -100 || exp > 100 {
+			if strings.HasPrefix(s[e+1:], "-") {
+				// Such a small number is only whole if it's zero, and f may
+				// have underflowed to zero, so check the mantissa instead
+				if strings.Trim(s[:e], "-0.") != "" {
+					return nil, s, errors.Errorf("value %s is not an integer", s)
+				}
+				return new(big.Int), s, nil
+			}
+			if f != math.Trunc(f) {
+				return nil, s, errors.Errorf("value %s is not an integer", s)
+			}
+			i, _ := big.NewFloat(f).Int(nil)
+			return i, s, nil
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	switch {
+	case !ok:
+		return nil, s, errors.Errorf("cannot unmarshal %s to an int", s)
+	case !r.IsInt():
+		return nil, s, errors.Errorf("value %s is not an integer", s)
+	}
+	return r.Num(), s, nil
+}
+
 func float64ToInt64(f float64) (int64, error) {
 	val := int64(f)
 	if math.Trunc(f) != float64(val) {
@@ -16,6 +16,7 @@ package nillabletypes
 
 import (
 	"database/sql/driver"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -181,9 +182,75 @@ func TestInt64_UnmarshalJSON(t *testing.T) {
 		{
 			name:    "Floating Point Number",
 			give:    toBytes(3.14159),
+			wantErr: true,
+		},
+		{
+			name:    "Whole Floating Point Number",
+			give:    []byte("3.0"),
 			want:    Int64{v: 3, present: true, initialized: true},
 			wantErr: false,
 		},
+		{
+			name:    "Exponent",
+			give:    []byte("1e3"),
+			want:    Int64{v: 1000, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Beyond Float Precision",
+			give:    []byte("9007199254740993"),
+			want:    Int64{v: 9007199254740993, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Max",
+			give:    []byte("9223372036854775807"),
+			want:    Int64{v: math.MaxInt64, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Min",
+			give:    []byte("-9223372036854775808"),
+			want:    Int64{v: math.MinInt64, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Max Whole Floating Point Number",
+			give:    []byte("9223372036854775807.0"),
+			want:    Int64{v: math.MaxInt64, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Above Max",
+			give:    []byte("9223372036854775808"),
+			wantErr: true,
+		},
+		{
+			name:    "Below Min",
+			give:    []byte("-9223372036854775809"),
+			wantErr: true,
+		},
+		{
+			name:    "Fraction Beyond Float Precision",
+			give:    []byte("9007199254740993.5"),
+			wantErr: true,
+		},
+		{
+			name:    "Tiny Fraction",
+			give:    []byte("1e-200"),
+			wantErr: true,
+		},
+		{
+			name:    "Underflowing Fraction",
+			give:    []byte("1e-400"),
+			wantErr: true,
+		},
+		{
+			name:    "Zero With Underflowing Exponent",
+			give:    []byte("-0.0e-400"),
+			want:    Int64{v: 0, present: true, initialized: true},
+			wantErr: false,
+		},
 		{
 			name:    "Floating Point Number (Out of Range)",
 			give:    toBytes(9999999999999999999.0),
@@ -0,0 +1,194 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"math"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// Int8 represents a nil-able int8, such as a MySQL TINYINT. Note that
+// PostgreSQL's int8 is a bigint, which is Int64.
+type Int8 Nillable[int8]
+
+// NewInt8 makes a new non-nil Int8
+func NewInt8(v int8) Int8 {
+	return Int8(New(v))
+}
+
+// NilInt8 makes a new nil Int8
+func NilInt8() Int8 {
+	return Int8(Nil[int8]())
+}
+
+// Int8 returns the built-in int8 value
+func (v Int8) Int8() int8 {
+	return v.v
+}
+
+// Nil returns whether this scalar is nil
+func (v Int8) Nil() bool {
+	return Nillable[int8](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Int8) Initialized() bool {
+	return Nillable[int8](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Int8) Set() bool {
+	return Nillable[int8](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Int8) State() State {
+	return Nillable[int8](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Int8) Reset() {
+	(*Nillable[int8])(v).Reset()
+}
+
+// String implements the fmt.Stringer interface
+func (v Int8) String() string {
+	return strconv.FormatInt(int64(v.v), 10)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *Int8) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		v.present = false
+		v.initialized = true
+		return nil
+	}
+	n, err := jsonToInt64(data)
+	if err != nil {
+		return err
+	}
+	i, err := int64ToInt8(n)
+	if err != nil {
+		return err
+	}
+	v.v = i
+	v.present = true
+	v.initialized = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Int8) MarshalJSON() ([]byte, error) {
+	return Nillable[int8](v).MarshalJSON()
+}
+
+// Value implements the driver.Valuer interface, returning the value as an int64
+func (v Int8) Value() (driver.Value, error) { //nolint:unparam
+	if !v.present {
+		return nil, nil
+	}
+	return int64(v.v), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Int8) Scan(src interface{}) error {
+	if src == nil {
+		*v = Int8{present: false, initialized: true}
+		return nil
+	}
+	switch t := src.(type) {
+	case int64:
+		i, err := int64ToInt8(t)
+		if err != nil {
+			return err
+		}
+		*v = Int8{v: i, present: true, initialized: true}
+		return nil
+	case float64:
+		val, err := float64ToInt8(t)
+		if err != nil {
+			return err
+		}
+		*v = Int8{v: val, present: true, initialized: true}
+		return nil
+	case bool:
+		if t {
+			*v = Int8{v: 1, present: true, initialized: true}
+		} else {
+			*v = Int8{v: 0, present: true, initialized: true}
+		}
+		return nil
+	case []byte:
+		s := string(t)
+		return v.scanString(s)
+	case string:
+		return v.scanString(t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to an int", src)
+}
+
+func (v *Int8) scanString(src string) error {
+	f, err := strconv.ParseFloat(src, 64)
+	if err != nil {
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to an int", src)
+	}
+	i, err := float64ToInt8(f)
+	if err != nil {
+		return err
+	}
+
+	*v = Int8{v: i, present: true, initialized: true}
+	return nil
+}
+
+// ScanInt64 implements the pgtype.Int64Scanner interface
+func (v *Int8) ScanInt64(src pgtype.Int8) error {
+	if !src.Valid {
+		*v = Int8{present: false, initialized: true}
+		return nil
+	}
+	i, err := int64ToInt8(src.Int64)
+	if err != nil {
+		return err
+	}
+	*v = Int8{v: i, present: true, initialized: true}
+	return nil
+}
+
+// Int64Value implements the pgtype.Int64Valuer interface
+func (v Int8) Int64Value() (pgtype.Int8, error) { //nolint:unparam
+	return pgtype.Int8{Int64: int64(v.v), Valid: v.present}, nil
+}
+
+func int64ToInt8(i int64) (int8, error) {
+	if math.MaxInt8 < i || math.MinInt8 > i {
+		return 0, errors.Errorf("value %v outside of the range of int8", i)
+	}
+	return int8(i), nil
+}
+
+func float64ToInt8(f float64) (int8, error) {
+	val := int8(f)
+	if math.Trunc(f) != float64(val) {
+		return 0, errors.Errorf("value %f outside of the range of int8", f)
+	}
+	return val, nil
+}
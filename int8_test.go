@@ -0,0 +1,134 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInt8(t *testing.T) {
+	assert.Equal(t, Int8{v: math.MaxInt8, present: true, initialized: true}, NewInt8(math.MaxInt8))
+	assert.Equal(t, Int8{present: false, initialized: true}, NilInt8())
+	assert.Equal(t, int8(math.MaxInt8), NewInt8(math.MaxInt8).Int8())
+	assert.True(t, NilInt8().Nil())
+	assert.Equal(t, StateValue, NewInt8(0).State())
+
+	v := NewInt8(1)
+	v.Reset()
+	assert.Equal(t, Int8{}, v)
+}
+
+func TestInt8_String(t *testing.T) {
+	assert.Equal(t, "127", NewInt8(math.MaxInt8).String())
+	assert.Equal(t, "-128", NewInt8(math.MinInt8).String())
+	assert.Equal(t, "0", NilInt8().String())
+}
+
+func TestInt8_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Int8
+		wantErr bool
+	}{
+		{"Max", `127`, NewInt8(math.MaxInt8), false},
+		{"Min", `-128`, NewInt8(math.MinInt8), false},
+		{"Whole Floating Point Number", `3.0`, NewInt8(3), false},
+		{"Null", `null`, NilInt8(), false},
+		{"Above Max", `128`, Int8{}, true},
+		{"Below Min", `-129`, Int8{}, true},
+		{"Fraction", `3.5`, Int8{}, true},
+		{"String", `"3"`, Int8{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Int8
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInt8_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Int8
+		want string
+	}{
+		{"Value", NewInt8(math.MaxInt8), `127`},
+		{"Nil", NilInt8(), `null`},
+		{"Uninitialized", Int8{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestInt8_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Int8
+		want driver.Value
+	}{
+		{"Nil", NilInt8(), nil},
+		{"Max", NewInt8(math.MaxInt8), int64(math.MaxInt8)},
+		{"Min", NewInt8(math.MinInt8), int64(math.MinInt8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInt8_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Int8
+		wantErr bool
+	}{
+		{"Nil", nil, NilInt8(), false},
+		{"True", true, NewInt8(1), false},
+		{"Int Max", int64(math.MaxInt8), NewInt8(math.MaxInt8), false},
+		{"Int Above Max", int64(128), Int8{}, true},
+		{"Int Below Min", int64(-129), Int8{}, true},
+		{"Float", 12.0, NewInt8(12), false},
+		{"Float Above Max", 128.0, Int8{}, true},
+		{"Byte Slice", []byte("127"), NewInt8(math.MaxInt8), false},
+		{"String", "-128", NewInt8(math.MinInt8), false},
+		{"String Above Max", "128", Int8{}, true},
+		{"Word", "twister", Int8{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Int8
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
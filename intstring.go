@@ -0,0 +1,112 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+)
+
+// baseInt64 and baseUint32 name the types embedded by the string-encoded
+// types, so that the embedded field doesn't hide their accessors
+type (
+	baseInt64  = Int64
+	baseUint32 = Uint32
+)
+
+// Int64String represents a nil-able int64 that is encoded as a JSON string,
+// e.g. "123", so that values beyond 2^53 survive JavaScript clients. It
+// behaves like Int64 in every other respect.
+type Int64String struct {
+	baseInt64
+}
+
+// NewInt64String makes a new non-nil Int64String
+func NewInt64String(v int64) Int64String {
+	return Int64String{NewInt64(v)}
+}
+
+// NilInt64String makes a new nil Int64String
+func NilInt64String() Int64String {
+	return Int64String{NilInt64()}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts both
+// the string and the numeric form.
+func (v *Int64String) UnmarshalJSON(data []byte) error {
+	data, err := unquoteJSONNumber(data)
+	if err != nil {
+		return err
+	}
+	return v.baseInt64.UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Int64String) MarshalJSON() ([]byte, error) {
+	if !v.initialized || !v.present {
+		return []byte{'n', 'u', 'l', 'l'}, nil
+	}
+	return json.Marshal(v.String())
+}
+
+// Uint32String represents a nil-able uint32 that is encoded as a JSON string,
+// e.g. "123". It behaves like Uint32 in every other respect.
+type Uint32String struct {
+	baseUint32
+}
+
+// NewUint32String makes a new non-nil Uint32String
+func NewUint32String(v uint32) Uint32String {
+	return Uint32String{NewUint32(v)}
+}
+
+// NilUint32String makes a new nil Uint32String
+func NilUint32String() Uint32String {
+	return Uint32String{NilUint32()}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts both
+// the string and the numeric form.
+func (v *Uint32String) UnmarshalJSON(data []byte) error {
+	data, err := unquoteJSONNumber(data)
+	if err != nil {
+		return err
+	}
+	return v.baseUint32.UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Uint32String) MarshalJSON() ([]byte, error) {
+	if !v.initialized || !v.present {
+		return []byte{'n', 'u', 'l', 'l'}, nil
+	}
+	return json.Marshal(v.String())
+}
+
+// unquoteJSONNumber returns the number inside a JSON string such as "123", or
+// data unchanged if it is not a string
+func unquoteJSONNumber(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != '"' {
+		return data, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if s == "" || s == "null" {
+		return nil, errors.Errorf("cannot unmarshal %q to an int", s)
+	}
+	return []byte(s), nil
+}
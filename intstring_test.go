@@ -0,0 +1,202 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInt64String_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    Int64String
+		wantErr bool
+	}{
+		{
+			name: "String",
+			give: []byte(`"9007199254740993"`),
+			want: NewInt64String(9007199254740993),
+		},
+		{
+			name: "Number",
+			give: []byte(`9007199254740993`),
+			want: NewInt64String(9007199254740993),
+		},
+		{
+			name: "Min String",
+			give: []byte(`"-9223372036854775808"`),
+			want: NewInt64String(math.MinInt64),
+		},
+		{
+			name: "Null",
+			give: []byte(`null`),
+			want: NilInt64String(),
+		},
+		{
+			name:    "Empty String",
+			give:    []byte(`""`),
+			wantErr: true,
+		},
+		{
+			name:    "Null String",
+			give:    []byte(`"null"`),
+			wantErr: true,
+		},
+		{
+			name:    "Non-numeric String",
+			give:    []byte(`"abc"`),
+			wantErr: true,
+		},
+		{
+			name:    "Out of Range String",
+			give:    []byte(`"9223372036854775808"`),
+			wantErr: true,
+		},
+		{
+			name:    "Boolean",
+			give:    []byte(`true`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Int64String{}
+			err := got.UnmarshalJSON(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInt64String_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Int64String
+		want string
+	}{
+		{"Uninitialized", Int64String{}, `null`},
+		{"Nil", NilInt64String(), `null`},
+		{"Zero", NewInt64String(0), `"0"`},
+		{"Max", NewInt64String(math.MaxInt64), `"9223372036854775807"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestUint32String_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    Uint32String
+		wantErr bool
+	}{
+		{
+			name: "String",
+			give: []byte(`"4294967295"`),
+			want: NewUint32String(math.MaxUint32),
+		},
+		{
+			name: "Number",
+			give: []byte(`12`),
+			want: NewUint32String(12),
+		},
+		{
+			name: "Null",
+			give: []byte(`null`),
+			want: NilUint32String(),
+		},
+		{
+			name:    "Negative String",
+			give:    []byte(`"-1"`),
+			wantErr: true,
+		},
+		{
+			name:    "Out of Range String",
+			give:    []byte(`"4294967296"`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Uint32String{}
+			err := got.UnmarshalJSON(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUint32String_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint32String
+		want string
+	}{
+		{"Uninitialized", Uint32String{}, `null`},
+		{"Nil", NilUint32String(), `null`},
+		{"Value", NewUint32String(math.MaxUint32), `"4294967295"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestIntString_Accessors(t *testing.T) {
+	assert.Equal(t, int64(9007199254740993), NewInt64String(9007199254740993).Int64())
+	assert.Equal(t, int64(0), NilInt64String().Int64())
+	assert.Equal(t, uint32(math.MaxUint32), NewUint32String(math.MaxUint32).Uint32())
+	assert.Equal(t, uint32(0), NilUint32String().Uint32())
+}
+
+func TestIntString_Struct(t *testing.T) {
+	type listing struct {
+		ID     Int64String  `json:"id"`
+		Parent Int64String  `json:"parent"`
+		Beds   Uint32String `json:"beds"`
+	}
+
+	var got listing
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"9007199254740993","parent":null}`), &got))
+	assert.Equal(t, StateValue, got.ID.State())
+	assert.Equal(t, StateNull, got.Parent.State())
+	assert.Equal(t, StateUnset, got.Beds.State())
+	assert.Equal(t, int64(9007199254740993), got.ID.Int64())
+
+	b, err := json.Marshal(got)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"9007199254740993","parent":null,"beds":null}`, string(b))
+
+	v, err := got.ID.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), v)
+}
@@ -0,0 +1,225 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// ParseError is returned by the lenient types when their JSON input can't be
+// parsed as the type they hold, and by Date and Time when text they scan can't
+// be parsed
+type ParseError struct {
+	// Type is the name of the type being decoded, e.g. "int64"
+	Type string
+	// Input is the JSON input, or the contents of the JSON string if it was
+	// one, or the scanned text
+	Input string
+	// Err is the underlying error, if any
+	Err error
+}
+
+// Error implements the error interface
+func (e *ParseError) Error() string {
+	msg := "cannot parse " + strconv.Quote(e.Input) + " as " + e.Type
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// baseInt32, baseFloat and baseBool name the types embedded by the lenient
+// types and RoundedFloat, along with baseInt64, so that the embedded field
+// doesn't hide their accessors
+type (
+	baseInt32 = Int32
+	baseFloat = Float
+	baseBool  = Bool
+)
+
+// LenientInt64 is an Int64 that also decodes numbers sent as JSON strings,
+// e.g. "42", and decodes "" as nil
+type LenientInt64 struct {
+	baseInt64
+}
+
+// NewLenientInt64 makes a new non-nil LenientInt64
+func NewLenientInt64(v int64) LenientInt64 {
+	return LenientInt64{NewInt64(v)}
+}
+
+// NilLenientInt64 makes a new nil LenientInt64
+func NilLenientInt64() LenientInt64 {
+	return LenientInt64{NilInt64()}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Errors are of type
+// *ParseError.
+func (v *LenientInt64) UnmarshalJSON(data []byte) error {
+	s, ok, err := lenientJSONString(data, "int64")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		v.baseInt64 = NilInt64()
+		return nil
+	}
+	i, err := jsonToInt64([]byte(s))
+	if err != nil {
+		return &ParseError{Type: "int64", Input: s, Err: err}
+	}
+	v.baseInt64 = NewInt64(i)
+	return nil
+}
+
+// LenientInt32 is an Int32 that also decodes numbers sent as JSON strings,
+// e.g. "42", and decodes "" as nil
+type LenientInt32 struct {
+	baseInt32
+}
+
+// NewLenientInt32 makes a new non-nil LenientInt32
+func NewLenientInt32(v int32) LenientInt32 {
+	return LenientInt32{NewInt32(v)}
+}
+
+// NilLenientInt32 makes a new nil LenientInt32
+func NilLenientInt32() LenientInt32 {
+	return LenientInt32{NilInt32()}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Errors are of type
+// *ParseError.
+func (v *LenientInt32) UnmarshalJSON(data []byte) error {
+	s, ok, err := lenientJSONString(data, "int32")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		v.baseInt32 = NilInt32()
+		return nil
+	}
+	n, err := jsonToInt64([]byte(s))
+	if err != nil {
+		return &ParseError{Type: "int32", Input: s, Err: err}
+	}
+	i, err := int64ToInt32(n)
+	if err != nil {
+		return &ParseError{Type: "int32", Input: s, Err: err}
+	}
+	v.baseInt32 = NewInt32(i)
+	return nil
+}
+
+// LenientFloat is a Float that also decodes numbers sent as JSON strings,
+// e.g. "4.5", and decodes "" as nil
+type LenientFloat struct {
+	baseFloat
+}
+
+// NewLenientFloat makes a new non-nil LenientFloat
+func NewLenientFloat(v float64) LenientFloat {
+	return LenientFloat{NewFloat(v)}
+}
+
+// NilLenientFloat makes a new nil LenientFloat
+func NilLenientFloat() LenientFloat {
+	return LenientFloat{NilFloat()}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Errors are of type
+// *ParseError.
+func (v *LenientFloat) UnmarshalJSON(data []byte) error {
+	s, ok, err := lenientJSONString(data, "float")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		v.baseFloat = NilFloat()
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return &ParseError{Type: "float", Input: s, Err: err}
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return &ParseError{Type: "float", Input: s}
+	}
+	v.baseFloat = NewFloat(f)
+	return nil
+}
+
+// LenientBool is a Bool that also decodes "Y"/"N", "yes"/"no", "T"/"F",
+// "1"/"0" and 1/0, in any case, and decodes "" as nil
+type LenientBool struct {
+	baseBool
+}
+
+// NewLenientBool makes a new non-nil LenientBool
+func NewLenientBool(v bool) LenientBool {
+	return LenientBool{NewBool(v)}
+}
+
+// NilLenientBool makes a new nil LenientBool
+func NilLenientBool() LenientBool {
+	return LenientBool{NilBool()}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Errors are of type
+// *ParseError.
+func (v *LenientBool) UnmarshalJSON(data []byte) error {
+	s, ok, err := lenientJSONString(data, "bool")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		v.baseBool = NilBool()
+		return nil
+	}
+	switch strings.ToLower(s) {
+	case "true", "t", "yes", "y", "1":
+		v.baseBool = NewBool(true)
+	case "false", "f", "no", "n", "0":
+		v.baseBool = NewBool(false)
+	default:
+		return &ParseError{Type: "bool", Input: s}
+	}
+	return nil
+}
+
+// lenientJSONString returns the contents of a JSON string, trimmed of spaces,
+// or any other JSON value as is. ok is false for null and for blank strings.
+func lenientJSONString(data []byte, typ string) (s string, ok bool, err error) {
+	s = string(data)
+	if s == "null" {
+		return "", false, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", false, &ParseError{Type: typ, Input: string(data), Err: err}
+		}
+		s = strings.TrimSpace(s)
+	}
+	return s, s != "", nil
+}
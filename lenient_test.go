@@ -0,0 +1,184 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLenientInt64_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    LenientInt64
+		wantErr bool
+	}{
+		{"Number", `42`, NewLenientInt64(42), false},
+		{"String", `"42"`, NewLenientInt64(42), false},
+		{"Padded String", `" -42 "`, NewLenientInt64(-42), false},
+		{"Large String", `"9007199254740993"`, NewLenientInt64(9007199254740993), false},
+		{"Empty String", `""`, NilLenientInt64(), false},
+		{"Null", `null`, NilLenientInt64(), false},
+		{"Fraction", `"4.5"`, LenientInt64{}, true},
+		{"Underflowing Fraction", `"1e-400"`, LenientInt64{}, true},
+		{"Word", `"forty-two"`, LenientInt64{}, true},
+		{"Boolean", `true`, LenientInt64{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LenientInt64{}
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertParseError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLenientInt32_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    LenientInt32
+		wantErr bool
+	}{
+		{"Number", `42`, NewLenientInt32(42), false},
+		{"String", `"42"`, NewLenientInt32(42), false},
+		{"Empty String", `""`, NilLenientInt32(), false},
+		{"Null", `null`, NilLenientInt32(), false},
+		{"Out of Range String", `"2147483648"`, LenientInt32{}, true},
+		{"Word", `"N/A"`, LenientInt32{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LenientInt32{}
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertParseError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLenientFloat_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    LenientFloat
+		wantErr bool
+	}{
+		{"Number", `4.5`, NewLenientFloat(4.5), false},
+		{"String", `"4.5"`, NewLenientFloat(4.5), false},
+		{"Integer String", `"42"`, NewLenientFloat(42), false},
+		{"Empty String", `""`, NilLenientFloat(), false},
+		{"Null", `null`, NilLenientFloat(), false},
+		{"NaN String", `"NaN"`, LenientFloat{}, true},
+		{"Word", `"abc"`, LenientFloat{}, true},
+		{"Boolean", `false`, LenientFloat{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LenientFloat{}
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertParseError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLenientBool_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    LenientBool
+		wantErr bool
+	}{
+		{"True", `true`, NewLenientBool(true), false},
+		{"False", `false`, NewLenientBool(false), false},
+		{"Y", `"Y"`, NewLenientBool(true), false},
+		{"N", `"N"`, NewLenientBool(false), false},
+		{"Yes", `"yes"`, NewLenientBool(true), false},
+		{"One String", `"1"`, NewLenientBool(true), false},
+		{"Zero String", `"0"`, NewLenientBool(false), false},
+		{"One", `1`, NewLenientBool(true), false},
+		{"Zero", `0`, NewLenientBool(false), false},
+		{"True String", `"TRUE"`, NewLenientBool(true), false},
+		{"Empty String", `""`, NilLenientBool(), false},
+		{"Null", `null`, NilLenientBool(), false},
+		{"Two", `2`, LenientBool{}, true},
+		{"Word", `"maybe"`, LenientBool{}, true},
+		{"Invalid JSON", `"Y`, LenientBool{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LenientBool{}
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertParseError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLenient_Accessors(t *testing.T) {
+	assert.Equal(t, int64(42), NewLenientInt64(42).Int64())
+	assert.Equal(t, int32(3), NewLenientInt32(3).Int32())
+	assert.Equal(t, 4.5, NewLenientFloat(4.5).Float())
+	assert.True(t, NewLenientBool(true).Bool())
+	assert.False(t, NilLenientBool().Bool())
+}
+
+func TestLenient_Struct(t *testing.T) {
+	type listing struct {
+		Beds   LenientInt32 `json:"beds"`
+		Price  LenientInt64 `json:"price"`
+		Baths  LenientFloat `json:"baths"`
+		Pool   LenientBool  `json:"pool"`
+		Strict Int32        `json:"strict"`
+	}
+
+	var got listing
+	assert.NoError(t, json.Unmarshal([]byte(`{"beds":"3","price":"","baths":"2.5","pool":"N"}`), &got))
+	assert.Equal(t, int32(3), got.Beds.Int32())
+	assert.Equal(t, StateNull, got.Price.State())
+	assert.Equal(t, 2.5, got.Baths.Float())
+	assert.Equal(t, NewLenientBool(false), got.Pool)
+	assert.Equal(t, StateUnset, got.Strict.State())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"strict":"3"}`), &got))
+
+	b, err := json.Marshal(got)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"beds":3,"price":null,"baths":2.5,"pool":false,"strict":null}`, string(b))
+}
+
+func TestParseError_Error(t *testing.T) {
+	assert.Equal(t, `cannot parse "maybe" as bool`, (&ParseError{Type: "bool", Input: "maybe"}).Error())
+
+	err := &ParseError{Type: "int32", Input: "x", Err: errors.New("bad")}
+	assert.Equal(t, `cannot parse "x" as int32: bad`, err.Error())
+	assert.Equal(t, "bad", err.Unwrap().Error())
+}
+
+func assertParseError(t *testing.T, wantErr bool, err error) {
+	t.Helper()
+	if !wantErr {
+		assert.NoError(t, err)
+		return
+	}
+	_, ok := err.(*ParseError)
+	assert.True(t, ok, "want *ParseError, got %T", err)
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mergepatch applies JSON Merge Patch (RFC 7396) semantics to structs
+// made of nillable fields.
+//
+// A patch is a struct decoded from a merge patch document. Each nillable field
+// of the patch is applied to the field of the same name in the target:
+//
+//   - an unset field (absent from the document) leaves the target untouched
+//   - a nil field (explicit null in the document) clears the target
+//   - a non-nil field overwrites the target
+//
+// Nested struct fields, and non-nil pointers to structs, are patched
+// recursively. Other fields are ignored since their zero value can't be told
+// apart from an absent member. For the same reason, a null for a nested
+// object, such as {"address":null}, is not applied: the target's struct is
+// kept rather than removed as RFC 7396 would.
+//
+// Patch fields should be nillable values rather than pointers to them.
+// encoding/json leaves a pointer nil for an explicit null, so a nil pointer to
+// a nillable field can only be treated as unset, and a null for it does not
+// clear the target.
+package mergepatch
+
+import (
+	"reflect"
+
+	"github.com/housecanary/nillabletypes"
+	"github.com/pkg/errors"
+)
+
+type stater interface {
+	State() nillabletypes.State
+}
+
+var staterType = reflect.TypeOf((*stater)(nil)).Elem()
+
+// Apply applies patch onto target. target must be a pointer to a struct, and
+// patch a struct or a pointer to a struct whose patched fields exist in target
+// with the same type. A nil pointer to a nillable patch field, such as a
+// *nillabletypes.String, is treated as unset, so an explicit null for it is
+// not applied; use a nillable value field to clear the target.
+func Apply(target, patch any) error {
+	tv := reflect.ValueOf(target)
+	if tv.Kind() != reflect.Pointer || tv.IsNil() || tv.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("mergepatch: target must be a non-nil pointer to a struct, got %T", target)
+	}
+	pv := reflect.ValueOf(patch)
+	if pv.Kind() == reflect.Pointer {
+		if pv.IsNil() {
+			return nil
+		}
+		pv = pv.Elem()
+	}
+	if pv.Kind() != reflect.Struct {
+		return errors.Errorf("mergepatch: patch must be a struct or a pointer to a struct, got %T", patch)
+	}
+	return apply(tv.Elem(), pv, "")
+}
+
+func apply(target, patch reflect.Value, path string) error {
+	pt := patch.Type()
+	for i := 0; i < pt.NumField(); i++ {
+		f := pt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := path + f.Name
+		pf := patch.Field(i)
+
+		switch {
+		case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Implements(staterType):
+			if pf.IsNil() || pf.Elem().Interface().(stater).State() == nillabletypes.StateUnset {
+				continue
+			}
+			tf, err := targetField(target, f, name)
+			if err != nil {
+				return err
+			}
+			if tf.Type() != f.Type {
+				return errors.Errorf("mergepatch: field %s is %v in the patch but %v in the target", name, f.Type, tf.Type())
+			}
+			if tf.IsNil() {
+				tf.Set(reflect.New(f.Type.Elem()))
+			}
+			tf.Elem().Set(pf.Elem())
+		case f.Type.Kind() != reflect.Pointer && f.Type.Implements(staterType):
+			if pf.Interface().(stater).State() == nillabletypes.StateUnset {
+				continue
+			}
+			tf, err := targetField(target, f, name)
+			if err != nil {
+				return err
+			}
+			if tf.Type() != f.Type {
+				return errors.Errorf("mergepatch: field %s is %v in the patch but %v in the target", name, f.Type, tf.Type())
+			}
+			tf.Set(pf)
+		case f.Type.Kind() == reflect.Struct:
+			tf, err := targetField(target, f, name)
+			if err != nil {
+				return err
+			}
+			if tf.Kind() != reflect.Struct {
+				return errors.Errorf("mergepatch: field %s is a struct in the patch but %v in the target", name, tf.Type())
+			}
+			if err := apply(tf, pf, name+"."); err != nil {
+				return err
+			}
+		case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() == reflect.Struct:
+			if pf.IsNil() {
+				continue
+			}
+			tf, err := targetField(target, f, name)
+			if err != nil {
+				return err
+			}
+			if tf.Kind() != reflect.Pointer || tf.Type().Elem().Kind() != reflect.Struct {
+				return errors.Errorf("mergepatch: field %s is a struct pointer in the patch but %v in the target", name, tf.Type())
+			}
+			if tf.IsNil() {
+				tf.Set(reflect.New(tf.Type().Elem()))
+			}
+			if err := apply(tf.Elem(), pf.Elem(), name+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func targetField(target reflect.Value, f reflect.StructField, name string) (reflect.Value, error) {
+	tf := target.FieldByName(f.Name)
+	if !tf.IsValid() {
+		return reflect.Value{}, errors.Errorf("mergepatch: target %v has no field %s", target.Type(), name)
+	}
+	if !tf.CanSet() {
+		return reflect.Value{}, errors.Errorf("mergepatch: field %s of target %v cannot be set", name, target.Type())
+	}
+	return tf, nil
+}
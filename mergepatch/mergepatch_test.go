@@ -0,0 +1,211 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergepatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	nt "github.com/housecanary/nillabletypes"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubStatus string
+
+type stubAddress struct {
+	Street nt.String `json:"street"`
+	Zip    nt.String `json:"zip"`
+}
+
+type stubListing struct {
+	ID      nt.UUID                   `json:"id"`
+	Active  nt.Bool                   `json:"active"`
+	Beds    nt.Int32                  `json:"beds"`
+	Price   nt.Int64                  `json:"price"`
+	Units   nt.Uint32                 `json:"units"`
+	Ratio   nt.Float                  `json:"ratio"`
+	Name    nt.String                 `json:"name"`
+	Listed  nt.Date                   `json:"listed"`
+	Updated nt.Time                   `json:"updated"`
+	Status  nt.Nillable[stubStatus]   `json:"status"`
+	Note    *nt.String                `json:"note"`
+	Address stubAddress               `json:"address"`
+	Mailing *stubAddress              `json:"mailing"`
+	Nested  struct{ Score nt.Float }  `json:"nested"`
+	Inner   *struct{ Score nt.Float } `json:"inner"`
+	Tags    []string                  `json:"tags"`
+}
+
+var stubTime = time.Date(2019, 11, 12, 10, 0, 0, 0, time.UTC)
+
+func stubTarget() stubListing {
+	return stubListing{
+		ID:      nt.NewUUID(uuid.MustParse("11111111-1111-1111-1111-111111111111")),
+		Active:  nt.NewBool(true),
+		Beds:    nt.NewInt32(3),
+		Price:   nt.NewInt64(500000),
+		Units:   nt.NewUint32(1),
+		Ratio:   nt.NewFloat(0.5),
+		Name:    nt.NewString("Main St"),
+		Listed:  nt.NewDate("2019-11-12"),
+		Updated: nt.NewTime(stubTime),
+		Status:  nt.New(stubStatus("active")),
+		Address: stubAddress{Street: nt.NewString("1 Main St"), Zip: nt.NewString("94105")},
+		Tags:    []string{"a"},
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want func(l *stubListing)
+	}{
+		{
+			name: "Empty Patch",
+			give: `{}`,
+			want: func(l *stubListing) {},
+		},
+		{
+			name: "Nulls Clear",
+			give: `{"id":null,"active":null,"beds":null,"price":null,"units":null,"ratio":null,"name":null,"listed":null,"updated":null,"status":null}`,
+			want: func(l *stubListing) {
+				l.ID = nt.NilUUID()
+				l.Active = nt.NilBool()
+				l.Beds = nt.NilInt32()
+				l.Price = nt.NilInt64()
+				l.Units = nt.NilUint32()
+				l.Ratio = nt.NilFloat()
+				l.Name = nt.NilString()
+				l.Listed = nt.NilDate()
+				l.Updated = nt.NilTime()
+				l.Status = nt.Nil[stubStatus]()
+			},
+		},
+		{
+			name: "Values Overwrite",
+			give: `{"active":false,"beds":4,"listed":"2020-01-02","updated":"2020-01-02T03:04:05Z","status":"sold"}`,
+			want: func(l *stubListing) {
+				l.Active = nt.NewBool(false)
+				l.Beds = nt.NewInt32(4)
+				l.Listed = nt.NewDate("2020-01-02")
+				l.Updated = nt.NewTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+				l.Status = nt.New(stubStatus("sold"))
+			},
+		},
+		{
+			name: "Pointer",
+			give: `{"note":"hello"}`,
+			want: func(l *stubListing) {
+				note := nt.NewString("hello")
+				l.Note = &note
+			},
+		},
+		{
+			name: "Nested Struct",
+			give: `{"address":{"zip":null},"nested":{"Score":1.5}}`,
+			want: func(l *stubListing) {
+				l.Address.Zip = nt.NilString()
+				l.Nested.Score = nt.NewFloat(1.5)
+			},
+		},
+		{
+			name: "Nested Pointer",
+			give: `{"mailing":{"street":"PO Box 1"},"inner":{"Score":2}}`,
+			want: func(l *stubListing) {
+				l.Mailing = &stubAddress{Street: nt.NewString("PO Box 1")}
+				l.Inner = &struct{ Score nt.Float }{Score: nt.NewFloat(2)}
+			},
+		},
+		{
+			// encoding/json can't tell null from an absent nested object, so
+			// the target's nested structs are kept
+			name: "Nested Null Ignored",
+			give: `{"address":null,"mailing":null,"nested":null,"inner":null}`,
+			want: func(l *stubListing) {},
+		},
+		{
+			name: "Plain Fields Ignored",
+			give: `{"tags":["b","c"]}`,
+			want: func(l *stubListing) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch stubListing
+			assert.NoError(t, json.Unmarshal([]byte(tt.give), &patch))
+
+			got := stubTarget()
+			assert.NoError(t, Apply(&got, patch))
+
+			want := stubTarget()
+			tt.want(&want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestApply_PointerNull(t *testing.T) {
+	// encoding/json leaves Note nil for null, which can't be told apart from
+	// an absent member, so the target's Note is kept
+	var patch stubListing
+	assert.NoError(t, json.Unmarshal([]byte(`{"note":null}`), &patch))
+	assert.Nil(t, patch.Note)
+
+	got := stubTarget()
+	note := nt.NewString("hello")
+	got.Note = &note
+	assert.NoError(t, Apply(&got, patch))
+
+	want := stubTarget()
+	want.Note = &note
+	assert.Equal(t, want, got)
+}
+
+func TestApply_PatchType(t *testing.T) {
+	type namePatch struct {
+		Name    nt.String `json:"name"`
+		Address struct {
+			Street nt.String `json:"street"`
+		} `json:"address"`
+	}
+
+	var patch namePatch
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":"Elm St","address":{"street":null}}`), &patch))
+
+	got := stubTarget()
+	assert.NoError(t, Apply(&got, &patch))
+
+	want := stubTarget()
+	want.Name = nt.NewString("Elm St")
+	want.Address.Street = nt.NilString()
+	assert.Equal(t, want, got)
+}
+
+func TestApply_Errors(t *testing.T) {
+	target := stubTarget()
+
+	assert.Error(t, Apply(target, stubListing{}), "non-pointer target")
+	assert.Error(t, Apply((*stubListing)(nil), stubListing{}), "nil target")
+	assert.Error(t, Apply(&target, "patch"), "non-struct patch")
+	assert.NoError(t, Apply(&target, (*stubListing)(nil)), "nil patch")
+
+	assert.Error(t, Apply(&target, struct{ Missing nt.String }{nt.NewString("x")}), "missing field")
+	assert.Error(t, Apply(&target, struct{ Name nt.Int64 }{nt.NewInt64(1)}), "mismatched type")
+	assert.NoError(t, Apply(&target, struct{ Missing nt.String }{}), "unset missing field")
+	assert.Equal(t, stubTarget(), target)
+}
@@ -0,0 +1,320 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+)
+
+// ErrCurrencyMismatch is returned by arithmetic on Money of different
+// currencies
+var ErrCurrencyMismatch = errors.New("money currencies don't match")
+
+// currencyMinorUnits maps the active ISO 4217 currency codes to the number of
+// digits after the decimal point of their minor unit
+var currencyMinorUnits = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0,
+	"XPF": 0,
+
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+
+	"CLF": 4, "UYW": 4,
+
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2,
+	"AWG": 2, "AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BMD": 2, "BND": 2,
+	"BOB": 2, "BOV": 2, "BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2,
+	"CAD": 2, "CDF": 2, "CHE": 2, "CHF": 2, "CHW": 2, "CNY": 2, "COP": 2, "COU": 2,
+	"CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2,
+	"ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2,
+	"GIP": 2, "GMD": 2, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "IRR": 2, "JMD": 2, "KES": 2, "KGS": 2, "KHR": 2,
+	"KPW": 2, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2,
+	"MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2,
+	"MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2, "MXV": 2, "MYR": 2, "MZN": 2, "NAD": 2,
+	"NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "PAB": 2, "PEN": 2, "PGK": 2,
+	"PHP": 2, "PKR": 2, "PLN": 2, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2, "SAR": 2,
+	"SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2, "SHP": 2, "SLE": 2, "SOS": 2,
+	"SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2, "THB": 2, "TJS": 2,
+	"TMT": 2, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "USD": 2,
+	"USN": 2, "UYU": 2, "UZS": 2, "VED": 2, "VES": 2, "XCD": 2, "XCG": 2, "YER": 2,
+	"ZAR": 2, "ZMW": 2, "ZWG": 2,
+}
+
+// CurrencyMinorUnits returns the number of digits after the decimal point of
+// the minor unit of an ISO 4217 currency, e.g. 2 for "USD" and 0 for "JPY",
+// and whether the currency is known
+func CurrencyMinorUnits(currency string) (int32, bool) {
+	units, ok := currencyMinorUnits[currency]
+	return units, ok
+}
+
+type money struct {
+	amount   decimal
+	currency string
+}
+
+// Money represents a nil-able amount of an ISO 4217 currency. Its amount
+// always has the scale of the currency's minor unit, e.g. 2 for USD.
+type Money Nillable[money]
+
+// NewMoney makes a new non-nil Money. It returns an error if currency isn't an
+// ISO 4217 code, if amount is nil, or if amount has digits smaller than the
+// currency's minor unit, e.g. 1.005 USD.
+func NewMoney(amount Decimal, currency string) (Money, error) {
+	units, ok := CurrencyMinorUnits(currency)
+	if !ok {
+		return Money{}, errors.Errorf("unknown currency %q", currency)
+	}
+	if !amount.present {
+		return Money{}, errors.New("money amount is nil")
+	}
+	rounded := amount.Round(units, RoundDown)
+	if rounded.Cmp(amount) != 0 {
+		return Money{}, errors.Errorf("amount %v has more than %d decimal places for %s", amount, units, currency)
+	}
+	return Money(New(money{amount: rounded.v, currency: currency})), nil
+}
+
+// ParseMoney makes a new non-nil Money from an amount such as "123.45"
+func ParseMoney(amount, currency string) (Money, error) {
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(d, currency)
+}
+
+// MustParseMoney is like ParseMoney but panics on error
+func MustParseMoney(amount, currency string) Money {
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NilMoney makes a new nil Money
+func NilMoney() Money {
+	return Money(Nil[money]())
+}
+
+// NewMoneyFromColumns makes a Money from an amount and a currency stored in
+// two columns. Both must be nil, which makes a nil Money, or neither.
+func NewMoneyFromColumns(amount Decimal, currency String) (Money, error) {
+	switch {
+	case !amount.present && !currency.present:
+		return NilMoney(), nil
+	case !amount.present || !currency.present:
+		return Money{}, errors.New("money amount and currency must both be nil or both be non-nil")
+	}
+	return NewMoney(amount, currency.String())
+}
+
+// Columns returns the amount and currency of this Money for storage in two
+// columns. Both are nil if this Money is nil.
+func (v Money) Columns() (Decimal, String) {
+	if !v.present {
+		return NilDecimal(), NilString()
+	}
+	return v.Amount(), NewString(v.v.currency)
+}
+
+// Amount returns the amount of this Money, which is nil if this Money is nil
+func (v Money) Amount() Decimal {
+	if !v.present {
+		return NilDecimal()
+	}
+	return Decimal(New(v.v.amount))
+}
+
+// Currency returns the ISO 4217 currency code of this Money
+func (v Money) Currency() string {
+	return v.v.currency
+}
+
+// Nil returns whether this scalar is nil
+func (v Money) Nil() bool {
+	return Nillable[money](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Money) Initialized() bool {
+	return Nillable[money](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Money) Set() bool {
+	return Nillable[money](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Money) State() State {
+	return Nillable[money](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Money) Reset() {
+	(*Nillable[money])(v).Reset()
+}
+
+// String implements the fmt.Stringer interface, e.g. "123.45 USD"
+func (v Money) String() string {
+	if !v.present {
+		return ""
+	}
+	return v.Amount().String() + " " + v.v.currency
+}
+
+// Cmp compares this Money to y and returns -1, 0 or +1. It returns
+// ErrCurrencyMismatch if they are of different currencies. Nil compares as
+// zero.
+func (v Money) Cmp(y Money) (int, error) {
+	if v.present && y.present && v.v.currency != y.v.currency {
+		return 0, ErrCurrencyMismatch
+	}
+	return v.Amount().Cmp(y.Amount()), nil
+}
+
+// Add returns the sum of this Money and y, or nil if either is nil. It
+// returns ErrCurrencyMismatch if they are of different currencies.
+func (v Money) Add(y Money) (Money, error) {
+	if !v.present || !y.present {
+		return NilMoney(), nil
+	}
+	if v.v.currency != y.v.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return NewMoney(v.Amount().Add(y.Amount()), v.v.currency)
+}
+
+// Sub returns the difference of this Money and y, or nil if either is nil. It
+// returns ErrCurrencyMismatch if they are of different currencies.
+func (v Money) Sub(y Money) (Money, error) {
+	if !v.present || !y.present {
+		return NilMoney(), nil
+	}
+	if v.v.currency != y.v.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return NewMoney(v.Amount().Sub(y.Amount()), v.v.currency)
+}
+
+// Mul returns this Money multiplied by factor, e.g. a tax rate, rounded to the
+// currency's minor unit with mode. It returns nil if either is nil.
+func (v Money) Mul(factor Decimal, mode RoundingMode) Money {
+	if !v.present || !factor.present {
+		return NilMoney()
+	}
+	amount := v.Amount().Mul(factor).Round(v.v.amount.scale, mode)
+	return Money(New(money{amount: amount.v, currency: v.v.currency}))
+}
+
+// Neg returns the negation of this Money
+func (v Money) Neg() Money {
+	if !v.present {
+		return v
+	}
+	return Money(New(money{amount: v.Amount().Neg().v, currency: v.v.currency}))
+}
+
+type moneyJSON struct {
+	Amount   Decimal `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts null or
+// an object such as {"amount":"123.45","currency":"USD"}.
+func (v *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		*v = NilMoney()
+		return nil
+	}
+	var t moneyJSON
+	if err := json.Unmarshal(data, &t); err != nil {
+		return errors.WithStack(err)
+	}
+	m, err := NewMoney(t.Amount, t.Currency)
+	if err != nil {
+		return err
+	}
+	*v = m
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Money is marshaled as
+// an object such as {"amount":"123.45","currency":"USD"}.
+func (v Money) MarshalJSON() ([]byte, error) {
+	if !v.initialized || !v.present {
+		return []byte{'n', 'u', 'l', 'l'}, nil
+	}
+	return json.Marshal(moneyJSON{Amount: v.Amount(), Currency: v.v.currency})
+}
+
+// Value implements the driver.Valuer interface, returning the text form of a
+// composite type of an amount and a currency, e.g. "(123.45,USD)". Use
+// Columns to store Money in two columns instead.
+func (v Money) Value() (driver.Value, error) { //nolint:unparam
+	if !v.present {
+		return nil, nil
+	}
+	return "(" + v.Amount().String() + "," + v.v.currency + ")", nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts the text form of a
+// composite type of an amount and a currency, e.g. "(123.45,USD)". Use
+// NewMoneyFromColumns to read Money from two columns instead.
+func (v *Money) Scan(src interface{}) error {
+	switch t := src.(type) {
+	case nil:
+		*v = NilMoney()
+		return nil
+	case []byte:
+		return v.scanString(string(t))
+	case string:
+		return v.scanString(t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to money", src)
+}
+
+func (v *Money) scanString(src string) error {
+	fields, ok := strings.CutPrefix(src, "(")
+	if ok {
+		fields, ok = strings.CutSuffix(fields, ")")
+	}
+	amount, currency, found := strings.Cut(fields, ",")
+	if !ok || !found {
+		return errors.Errorf("cannot scan value %q to money", src)
+	}
+	amount = strings.Trim(amount, `"`)
+	currency = strings.Trim(strings.TrimSpace(currency), `"`)
+	if amount == "" && currency == "" {
+		// A composite whose fields are both NULL
+		*v = NilMoney()
+		return nil
+	}
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		return err
+	}
+	*v = m
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Decimal
+		currency string
+		want     string
+		wantErr  bool
+	}{
+		{"USD", MustParseDecimal("123.45"), "USD", "123.45 USD", false},
+		{"Padded to Minor Unit", MustParseDecimal("5"), "USD", "5.00 USD", false},
+		{"Trailing Zeros", MustParseDecimal("5.000"), "USD", "5.00 USD", false},
+		{"JPY", MustParseDecimal("1000"), "JPY", "1000 JPY", false},
+		{"KWD", MustParseDecimal("1.5"), "KWD", "1.500 KWD", false},
+		{"Negative", MustParseDecimal("-0.01"), "EUR", "-0.01 EUR", false},
+		{"Below Minor Unit", MustParseDecimal("1.005"), "USD", "", true},
+		{"Fractional JPY", MustParseDecimal("0.5"), "JPY", "", true},
+		{"Unknown Currency", MustParseDecimal("1"), "XYZ", "", true},
+		{"Lowercase Currency", MustParseDecimal("1"), "usd", "", true},
+		{"Nil Amount", NilDecimal(), "USD", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMoney(tt.amount, tt.currency)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestMoney_Accessors(t *testing.T) {
+	m := MustParseMoney("19.9", "GBP")
+	assert.Equal(t, MustParseDecimal("19.90"), m.Amount())
+	assert.Equal(t, "GBP", m.Currency())
+	assert.Equal(t, StateValue, m.State())
+	assert.False(t, m.Nil())
+
+	assert.True(t, NilMoney().Amount().Nil())
+	assert.Equal(t, StateNull, NilMoney().State())
+	assert.Equal(t, StateUnset, Money{}.State())
+
+	m.Reset()
+	assert.Equal(t, Money{}, m)
+
+	units, ok := CurrencyMinorUnits("JPY")
+	assert.True(t, ok)
+	assert.Equal(t, int32(0), units)
+	_, ok = CurrencyMinorUnits("ABC")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { MustParseMoney("1", "ABC") })
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	usd := func(s string) Money { return MustParseMoney(s, "USD") }
+
+	got, err := usd("10.50").Add(usd("0.75"))
+	assert.NoError(t, err)
+	assert.Equal(t, usd("11.25"), got)
+
+	got, err = usd("10.50").Sub(usd("20"))
+	assert.NoError(t, err)
+	assert.Equal(t, usd("-9.50"), got)
+
+	got, err = usd("10.50").Add(NilMoney())
+	assert.NoError(t, err)
+	assert.True(t, got.Nil())
+
+	_, err = usd("10.50").Add(MustParseMoney("10.50", "EUR"))
+	assert.Equal(t, ErrCurrencyMismatch, err)
+	_, err = usd("10.50").Sub(MustParseMoney("10.50", "EUR"))
+	assert.Equal(t, ErrCurrencyMismatch, err)
+
+	c, err := usd("10.50").Cmp(usd("10.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c)
+	c, err = usd("1").Cmp(usd("2"))
+	assert.NoError(t, err)
+	assert.Equal(t, -1, c)
+	_, err = usd("1").Cmp(MustParseMoney("1", "CAD"))
+	assert.Equal(t, ErrCurrencyMismatch, err)
+
+	rate := MustParseDecimal("0.0825")
+	assert.Equal(t, usd("1.65"), usd("19.99").Mul(rate, RoundHalfUp))
+	assert.Equal(t, usd("1.64"), usd("19.99").Mul(rate, RoundDown))
+	assert.Equal(t, MustParseMoney("82", "JPY"), MustParseMoney("1000", "JPY").Mul(rate, RoundHalfEven))
+	assert.Equal(t, MustParseMoney("83", "JPY"), MustParseMoney("1000", "JPY").Mul(rate, RoundHalfUp))
+	assert.True(t, usd("1").Mul(NilDecimal(), RoundHalfUp).Nil())
+
+	assert.Equal(t, usd("-1.50"), usd("1.5").Neg())
+	assert.True(t, NilMoney().Neg().Nil())
+}
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Money
+		wantErr bool
+	}{
+		{"Object", `{"amount":"123.45","currency":"USD"}`, MustParseMoney("123.45", "USD"), false},
+		{"Number Amount", `{"amount":123.45,"currency":"USD"}`, MustParseMoney("123.45", "USD"), false},
+		{"Null", `null`, NilMoney(), false},
+		{"Unknown Currency", `{"amount":"1","currency":"ABC"}`, Money{}, true},
+		{"Missing Currency", `{"amount":"1"}`, Money{}, true},
+		{"Null Amount", `{"amount":null,"currency":"USD"}`, Money{}, true},
+		{"Below Minor Unit", `{"amount":"1.001","currency":"USD"}`, Money{}, true},
+		{"String", `"1 USD"`, Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	type listing struct {
+		Price Money `json:"price"`
+		Fee   Money `json:"fee"`
+		Tax   Money `json:"tax"`
+	}
+	give := listing{Price: MustParseMoney("123.4", "USD"), Fee: NilMoney()}
+
+	b, err := json.Marshal(give)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":{"amount":"123.40","currency":"USD"},"fee":null,"tax":null}`, string(b))
+
+	var got listing
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, give.Price, got.Price)
+	assert.Equal(t, StateNull, got.Fee.State())
+}
+
+func TestMoney_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Money
+		want driver.Value
+	}{
+		{"Nil", NilMoney(), nil},
+		{"USD", MustParseMoney("-123.45", "USD"), "(-123.45,USD)"},
+		{"JPY", MustParseMoney("1000", "JPY"), "(1000,JPY)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Money
+		wantErr bool
+	}{
+		{"Nil", nil, NilMoney(), false},
+		{"Composite", "(123.45,USD)", MustParseMoney("123.45", "USD"), false},
+		{"Composite Bytes", []byte("(1000,JPY)"), MustParseMoney("1000", "JPY"), false},
+		{"Quoted Fields", `("123.45","USD")`, MustParseMoney("123.45", "USD"), false},
+		{"Null Fields", "(,)", NilMoney(), false},
+		{"Null Currency", "(1,)", Money{}, true},
+		{"Unknown Currency", "(1,ABC)", Money{}, true},
+		{"No Parentheses", "123.45,USD", Money{}, true},
+		{"One Field", "(123.45)", Money{}, true},
+		{"Int", int64(1), Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Columns(t *testing.T) {
+	amount, currency := MustParseMoney("5", "EUR").Columns()
+	assert.Equal(t, MustParseDecimal("5.00"), amount)
+	assert.Equal(t, NewString("EUR"), currency)
+
+	got, err := NewMoneyFromColumns(amount, currency)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParseMoney("5", "EUR"), got)
+
+	amount, currency = NilMoney().Columns()
+	assert.True(t, amount.Nil())
+	assert.True(t, currency.Nil())
+
+	got, err = NewMoneyFromColumns(amount, currency)
+	assert.NoError(t, err)
+	assert.Equal(t, NilMoney(), got)
+
+	_, err = NewMoneyFromColumns(MustParseDecimal("5"), NilString())
+	assert.Error(t, err)
+	_, err = NewMoneyFromColumns(NilDecimal(), NewString("EUR"))
+	assert.Error(t, err)
+	_, err = NewMoneyFromColumns(MustParseDecimal("5"), NewString("XYZ"))
+	assert.Error(t, err)
+}
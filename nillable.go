@@ -0,0 +1,186 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+)
+
+// State describes which of its three states a nil-able value is in
+type State int
+
+const (
+	// StateUnset means the value was never initialized, e.g. its field was
+	// absent from a decoded JSON body
+	StateUnset State = iota
+	// StateNull means the value was explicitly set to nil
+	StateNull
+	// StateValue means the value was set to a non-nil value
+	StateValue
+)
+
+// String implements the fmt.Stringer interface
+func (s State) String() string {
+	switch s {
+	case StateUnset:
+		return "unset"
+	case StateNull:
+		return "null"
+	case StateValue:
+		return "value"
+	}
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Nillable represents a nil-able value of any type. The concrete types in this
+// package (Bool, Int64, String, ...) are defined on top of it, and it can be
+// used directly to make nil-able versions of other types, e.g.
+//
+//	type Status string
+//	type NillableStatus = nillabletypes.Nillable[Status]
+type Nillable[T any] struct {
+	v           T
+	present     bool
+	initialized bool
+}
+
+// New makes a new non-nil Nillable
+func New[T any](v T) Nillable[T] {
+	return Nillable[T]{v: v, present: true, initialized: true}
+}
+
+// Nil makes a new nil Nillable
+func Nil[T any]() Nillable[T] {
+	return Nillable[T]{present: false, initialized: true}
+}
+
+// Get returns the underlying value, or the zero value of T if nil
+func (v Nillable[T]) Get() T {
+	return v.v
+}
+
+// Nil returns whether this value is nil
+func (v Nillable[T]) Nil() bool {
+	return !v.present
+}
+
+// Initialized returns whether this value has been set, either to nil or to a
+// non-nil value
+func (v Nillable[T]) Initialized() bool {
+	return v.initialized
+}
+
+// Set is a synonym for Initialized
+func (v Nillable[T]) Set() bool {
+	return v.initialized
+}
+
+// State returns whether this value is unset, nil or non-nil
+func (v Nillable[T]) State() State {
+	switch {
+	case !v.initialized:
+		return StateUnset
+	case !v.present:
+		return StateNull
+	}
+	return StateValue
+}
+
+// Reset returns this value to the unset state
+func (v *Nillable[T]) Reset() {
+	*v = Nillable[T]{}
+}
+
+// String implements the fmt.Stringer interface
+func (v Nillable[T]) String() string {
+	return fmt.Sprint(v.v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *Nillable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		v.present = false
+		v.initialized = true
+		return nil
+	}
+	err := json.Unmarshal(data, &v.v)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	v.present = true
+	v.initialized = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Nillable[T]) MarshalJSON() ([]byte, error) {
+	if !v.initialized || !v.present {
+		return []byte{'n', 'u', 'l', 'l'}, nil
+	}
+	return json.Marshal(v.v)
+}
+
+// Value implements the driver.Valuer interface. Values of T that are not
+// already valid driver values are converted with the default converter, which
+// honors T's own driver.Valuer implementation if it has one.
+func (v Nillable[T]) Value() (driver.Value, error) {
+	if !v.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v.v)
+}
+
+// Scan implements the sql.Scanner interface. It accepts nil, values of type T,
+// values whose kind matches T's (e.g. a string for a named string type), and
+// anything T itself can scan if *T implements sql.Scanner.
+func (v *Nillable[T]) Scan(src any) error {
+	if src == nil {
+		*v = Nillable[T]{present: false, initialized: true}
+		return nil
+	}
+	if t, ok := src.(T); ok {
+		*v = Nillable[T]{v: t, present: true, initialized: true}
+		return nil
+	}
+
+	var t T
+	if s, ok := any(&t).(sql.Scanner); ok {
+		if err := s.Scan(src); err != nil {
+			return errors.WithStack(err)
+		}
+		*v = Nillable[T]{v: t, present: true, initialized: true}
+		return nil
+	}
+
+	dst := reflect.ValueOf(&t).Elem()
+	sv := reflect.ValueOf(src)
+	if b, ok := src.([]byte); ok && dst.Kind() == reflect.String {
+		sv = reflect.ValueOf(string(b))
+	}
+	if sv.Kind() == dst.Kind() && sv.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(sv.Convert(dst.Type()))
+		*v = Nillable[T]{v: t, present: true, initialized: true}
+		return nil
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to a %[2]T", src, t)
+}
@@ -0,0 +1,305 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubStatus string
+
+type stubCode struct {
+	v string
+}
+
+func (c stubCode) Value() (driver.Value, error) {
+	return strings.ToUpper(c.v), nil
+}
+
+func (c *stubCode) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return errors.Errorf("cannot scan %T to a stubCode", src)
+	}
+	c.v = strings.ToLower(s)
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, Nillable[stubStatus]{v: "active", present: true, initialized: true}, New(stubStatus("active")))
+	assert.Equal(t, Nillable[int]{v: 0, present: true, initialized: true}, New(0))
+}
+
+func TestNil(t *testing.T) {
+	assert.Equal(t, Nillable[stubStatus]{present: false, initialized: true}, Nil[stubStatus]())
+}
+
+func TestNillable_Get(t *testing.T) {
+	assert.Equal(t, stubStatus("active"), New(stubStatus("active")).Get())
+	assert.Equal(t, stubStatus(""), Nil[stubStatus]().Get())
+}
+
+func TestNillable_Nil(t *testing.T) {
+	assert.True(t, Nil[stubStatus]().Nil())
+	assert.True(t, Nillable[stubStatus]{}.Nil())
+	assert.False(t, New(stubStatus("")).Nil())
+}
+
+func TestNillable_String(t *testing.T) {
+	assert.Equal(t, "active", New(stubStatus("active")).String())
+	assert.Equal(t, "42", New(42).String())
+}
+
+func TestNillable_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    Nillable[stubStatus]
+		wantErr bool
+	}{
+		{
+			name: "String",
+			give: toJSONBytes("active"),
+			want: Nillable[stubStatus]{v: "active", present: true, initialized: true},
+		},
+		{
+			name: "Null",
+			give: toJSONBytes(nil),
+			want: Nillable[stubStatus]{present: false, initialized: true},
+		},
+		{
+			name:    "Number",
+			give:    toJSONBytes(12),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Nillable[stubStatus]{}
+			err := got.UnmarshalJSON(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNillable_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Nillable[stubStatus]
+		want []byte
+	}{
+		{
+			name: "Uninitialized",
+			give: Nillable[stubStatus]{},
+			want: toJSONBytes(nil),
+		},
+		{
+			name: "Nil",
+			give: Nil[stubStatus](),
+			want: toJSONBytes(nil),
+		},
+		{
+			name: "Value",
+			give: New(stubStatus("active")),
+			want: toJSONBytes("active"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNillable_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give driver.Valuer
+		want driver.Value
+	}{
+		{
+			name: "Nil",
+			give: Nil[stubStatus](),
+			want: nil,
+		},
+		{
+			name: "Named String",
+			give: New(stubStatus("active")),
+			want: "active",
+		},
+		{
+			name: "Int32",
+			give: New(int32(12)),
+			want: int64(12),
+		},
+		{
+			name: "Valuer",
+			give: New(stubCode{v: "abc"}),
+			want: "ABC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNillable_Scan(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		got := Nillable[stubStatus]{}
+		assert.NoError(t, got.Scan(nil))
+		assert.Equal(t, Nil[stubStatus](), got)
+	})
+	t.Run("Same Type", func(t *testing.T) {
+		got := Nillable[int64]{}
+		assert.NoError(t, got.Scan(int64(7)))
+		assert.Equal(t, New(int64(7)), got)
+	})
+	t.Run("Same Kind", func(t *testing.T) {
+		got := Nillable[stubStatus]{}
+		assert.NoError(t, got.Scan("active"))
+		assert.Equal(t, New(stubStatus("active")), got)
+	})
+	t.Run("Byte Slice", func(t *testing.T) {
+		got := Nillable[stubStatus]{}
+		assert.NoError(t, got.Scan([]byte("active")))
+		assert.Equal(t, New(stubStatus("active")), got)
+	})
+	t.Run("Scanner", func(t *testing.T) {
+		got := Nillable[stubCode]{}
+		assert.NoError(t, got.Scan("ABC"))
+		assert.Equal(t, New(stubCode{v: "abc"}), got)
+	})
+	t.Run("Scanner Error", func(t *testing.T) {
+		got := Nillable[stubCode]{}
+		assert.Error(t, got.Scan(int64(1)))
+		assert.Equal(t, Nillable[stubCode]{}, got)
+	})
+	t.Run("Different Kind", func(t *testing.T) {
+		got := Nillable[stubStatus]{}
+		assert.Error(t, got.Scan(int64(1)))
+		assert.Equal(t, Nillable[stubStatus]{}, got)
+	})
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "unset", StateUnset.String())
+	assert.Equal(t, "null", StateNull.String())
+	assert.Equal(t, "value", StateValue.String())
+	assert.Equal(t, "State(7)", State(7).String())
+}
+
+func TestNillable_State(t *testing.T) {
+	assert.Equal(t, StateUnset, Nillable[stubStatus]{}.State())
+	assert.Equal(t, StateNull, Nil[stubStatus]().State())
+	assert.Equal(t, StateValue, New(stubStatus("")).State())
+
+	assert.False(t, Nillable[stubStatus]{}.Initialized())
+	assert.False(t, Nillable[stubStatus]{}.Set())
+	assert.True(t, Nil[stubStatus]().Initialized())
+	assert.True(t, New(stubStatus("")).Set())
+}
+
+func TestNillable_Reset(t *testing.T) {
+	v := New(stubStatus("active"))
+	v.Reset()
+	assert.Equal(t, Nillable[stubStatus]{}, v)
+	assert.Equal(t, StateUnset, v.State())
+}
+
+func TestTypes_State(t *testing.T) {
+	type patch struct {
+		Bool   Bool   `json:"bool"`
+		Int32  Int32  `json:"int32"`
+		Int64  Int64  `json:"int64"`
+		Uint32 Uint32 `json:"uint32"`
+		Float  Float  `json:"float"`
+		String String `json:"string"`
+		UUID   UUID   `json:"uuid"`
+		Time   Time   `json:"time"`
+		Date   Date   `json:"date"`
+	}
+	type stater interface {
+		Initialized() bool
+		Set() bool
+		State() State
+	}
+	fields := func(p *patch) map[string]stater {
+		return map[string]stater{
+			"bool": p.Bool, "int32": p.Int32, "int64": p.Int64, "uint32": p.Uint32, "float": p.Float,
+			"string": p.String, "uuid": p.UUID, "time": p.Time, "date": p.Date,
+		}
+	}
+
+	tests := []struct {
+		name string
+		give string
+		want State
+	}{
+		{
+			name: "Absent",
+			give: `{}`,
+			want: StateUnset,
+		},
+		{
+			name: "Null",
+			give: `{"bool":null,"int32":null,"int64":null,"uint32":null,"float":null,"string":null,"uuid":null,"time":null,"date":null}`,
+			want: StateNull,
+		},
+		{
+			name: "Value",
+			give: `{"bool":false,"int32":0,"int64":0,"uint32":0,"float":0,"string":"","uuid":"` + stubUUIDString + `","time":"2019-11-12T00:00:00Z","date":"2019-11-12"}`,
+			want: StateValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p patch
+			assert.NoError(t, json.Unmarshal([]byte(tt.give), &p))
+			for name, f := range fields(&p) {
+				assert.Equal(t, tt.want, f.State(), name)
+				assert.Equal(t, tt.want != StateUnset, f.Initialized(), name)
+				assert.Equal(t, tt.want != StateUnset, f.Set(), name)
+			}
+		})
+	}
+}
+
+func TestTypes_Reset(t *testing.T) {
+	b := NewBool(true)
+	b.Reset()
+	assert.Equal(t, Bool{}, b)
+
+	d := NilDate()
+	d.Reset()
+	assert.Equal(t, StateUnset, d.State())
+
+	tm := NewTime(time.Now())
+	tm.Reset()
+	assert.False(t, tm.Initialized())
+}
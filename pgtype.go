@@ -0,0 +1,63 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RegisterTypes registers the scalar types in this package, the JSON variants
+// of them such as Int64String and LenientBool, their pointers and their Arrays
+// with m, so that pgx picks the matching PostgreSQL codec even when the OID of
+// a parameter or result is unknown. RoundedFloat isn't registered since each
+// precision is a different type, nor is Money since its composite type is
+// defined by the database. It's typically called from a pgx connection's
+// AfterConnect hook:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		nillabletypes.RegisterTypes(conn.TypeMap())
+//		return nil
+//	}
+func RegisterTypes(m *pgtype.Map) {
+	registerPgType[Bool](m, "bool")
+	registerPgType[Int8](m, "int2")
+	registerPgType[Int16](m, "int2")
+	registerPgType[Int32](m, "int4")
+	registerPgType[Int64](m, "int8")
+	registerPgType[Uint8](m, "int2")
+	registerPgType[Uint16](m, "int4")
+	registerPgType[Uint32](m, "int8")
+	registerPgType[Uint64](m, "numeric")
+	registerPgType[Float](m, "float8")
+	registerPgType[Decimal](m, "numeric")
+	registerPgType[String](m, "text")
+	registerPgType[UUID](m, "uuid")
+	registerPgType[Date](m, "date")
+	registerPgType[Time](m, "timestamptz")
+	registerPgType[Int64String](m, "int8")
+	registerPgType[Uint32String](m, "int8")
+	registerPgType[LenientInt32](m, "int4")
+	registerPgType[LenientInt64](m, "int8")
+	registerPgType[LenientFloat](m, "float8")
+	registerPgType[LenientBool](m, "bool")
+}
+
+func registerPgType[T any](m *pgtype.Map, name string) {
+	var v T
+	m.RegisterDefaultPgType(v, name)
+	m.RegisterDefaultPgType(&v, name)
+	m.RegisterDefaultPgType(Array[T](nil), "_"+name)
+	m.RegisterDefaultPgType(&Array[T]{}, "_"+name)
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertPgRoundTrip encodes give with the codec for oid in both wire formats
+// and scans the result into a new value of the same type
+func assertPgRoundTrip(t *testing.T, oid uint32, give any) {
+	t.Helper()
+	m := pgtype.NewMap()
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(oid, format, give, []byte{})
+		assert.NoError(t, err)
+
+		got := reflect.New(reflect.TypeOf(give))
+		assert.NoError(t, m.Scan(oid, format, buf, got.Interface()))
+		assert.Equal(t, give, got.Elem().Interface(), "format %d", format)
+	}
+}
+
+func TestPgtype_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		oid  uint32
+		give any
+	}{
+		{"Bool", pgtype.BoolOID, NewBool(true)},
+		{"Nil Bool", pgtype.BoolOID, NilBool()},
+		{"Int32", pgtype.Int4OID, NewInt32(math.MinInt32)},
+		{"Nil Int32", pgtype.Int4OID, NilInt32()},
+		{"Int64", pgtype.Int8OID, NewInt64(math.MaxInt64)},
+		{"Nil Int64", pgtype.Int8OID, NilInt64()},
+		{"Uint32", pgtype.Int8OID, NewUint32(math.MaxUint32)},
+		{"Nil Uint32", pgtype.Int8OID, NilUint32()},
+		{"Int8", pgtype.Int2OID, NewInt8(math.MinInt8)},
+		{"Nil Int8", pgtype.Int2OID, NilInt8()},
+		{"Int16", pgtype.Int2OID, NewInt16(math.MaxInt16)},
+		{"Nil Int16", pgtype.Int2OID, NilInt16()},
+		{"Uint8", pgtype.Int2OID, NewUint8(math.MaxUint8)},
+		{"Nil Uint8", pgtype.Int2OID, NilUint8()},
+		{"Uint16", pgtype.Int4OID, NewUint16(math.MaxUint16)},
+		{"Nil Uint16", pgtype.Int4OID, NilUint16()},
+		{"Uint64", pgtype.NumericOID, NewUint64(math.MaxUint64)},
+		{"Nil Uint64", pgtype.NumericOID, NilUint64()},
+		{"Float", pgtype.Float8OID, NewFloat(3.14159)},
+		{"Nil Float", pgtype.Float8OID, NilFloat()},
+		{"Decimal", pgtype.NumericOID, MustParseDecimal("-12345678901234567890.1234567890")},
+		{"Small Decimal", pgtype.NumericOID, MustParseDecimal("0.00012")},
+		{"Integer Decimal", pgtype.NumericOID, MustParseDecimal("1200")},
+		{"Zero Decimal", pgtype.NumericOID, MustParseDecimal("0.00")},
+		{"Nil Decimal", pgtype.NumericOID, NilDecimal()},
+		{"String", pgtype.TextOID, NewString("hello")},
+		{"Empty String", pgtype.TextOID, NewString("")},
+		{"Nil String", pgtype.TextOID, NilString()},
+		{"Date", pgtype.DateOID, NewDate("2019-11-12")},
+		{"Infinite Date", pgtype.DateOID, NewInfiniteDate()},
+		{"Negative Infinite Date", pgtype.DateOID, NewNegInfiniteDate()},
+		{"Nil Date", pgtype.DateOID, NilDate()},
+		{"Timestamp", pgtype.TimestampOID, NewTime(time.Date(2019, 11, 12, 10, 0, 0, 0, time.UTC))},
+		{"Infinite Timestamp", pgtype.TimestampOID, NewInfiniteTime()},
+		{"Negative Infinite Timestamp", pgtype.TimestampOID, NewNegInfiniteTime()},
+		{"Infinite Timestamptz", pgtype.TimestamptzOID, NewInfiniteTime()},
+		{"Negative Infinite Timestamptz", pgtype.TimestamptzOID, NewNegInfiniteTime()},
+		{"Nil Timestamptz", pgtype.TimestamptzOID, NilTime()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPgRoundTrip(t, tt.oid, tt.give)
+		})
+	}
+}
+
+func TestInt32_ScanInt64(t *testing.T) {
+	got := Int32{}
+	assert.Error(t, got.ScanInt64(pgtype.Int8{Int64: math.MaxInt32 + 1, Valid: true}))
+	assert.Equal(t, Int32{}, got)
+
+	assert.NoError(t, got.ScanInt64(pgtype.Int8{Int64: 12, Valid: true}))
+	assert.Equal(t, NewInt32(12), got)
+}
+
+func TestUint32_ScanInt64(t *testing.T) {
+	got := Uint32{}
+	assert.Error(t, got.ScanInt64(pgtype.Int8{Int64: -1, Valid: true}))
+	assert.Equal(t, Uint32{}, got)
+
+	assert.NoError(t, got.ScanInt64(pgtype.Int8{Valid: false}))
+	assert.Equal(t, NilUint32(), got)
+}
+
+func TestTime_ScanDate(t *testing.T) {
+	got := Time{}
+	assert.NoError(t, got.ScanDate(pgtype.Date{Time: time.Date(2019, 11, 12, 0, 0, 0, 0, time.UTC), Valid: true}))
+	assert.Equal(t, NewTime(time.Date(2019, 11, 12, 0, 0, 0, 0, time.UTC)), got)
+
+	assert.NoError(t, got.ScanDate(pgtype.Date{}))
+	assert.Equal(t, NilTime(), got)
+
+	assert.NoError(t, got.ScanDate(pgtype.Date{InfinityModifier: pgtype.Infinity, Valid: true}))
+	assert.Equal(t, NewInfiniteTime(), got)
+}
+
+func TestRegisterTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		give any
+		want string
+	}{
+		{"Bool", NewBool(true), "bool"},
+		{"Int32", NewInt32(1), "int4"},
+		{"Int64", NewInt64(1), "int8"},
+		{"Uint32", NewUint32(1), "int8"},
+		{"Int8", NewInt8(1), "int2"},
+		{"Int16", NewInt16(1), "int2"},
+		{"Uint8", NewUint8(1), "int2"},
+		{"Uint16", NewUint16(1), "int4"},
+		{"Uint64", NewUint64(math.MaxUint64), "numeric"},
+		{"Float", NewFloat(1.5), "float8"},
+		{"Decimal", NewDecimal(150, 2), "numeric"},
+		{"String", NewString("a"), "text"},
+		{"UUID", NewUUID(stubUUID), "uuid"},
+		{"Date", NewDate("2019-11-12"), "date"},
+		{"Time", NewTime(time.Date(2019, 11, 12, 10, 0, 0, 0, time.UTC)), "timestamptz"},
+		{"Int64String", NewInt64String(1), "int8"},
+		{"Uint32String", NewUint32String(math.MaxUint32), "int8"},
+		{"LenientInt32", NewLenientInt32(1), "int4"},
+		{"LenientInt64", NewLenientInt64(1), "int8"},
+		{"LenientFloat", NewLenientFloat(1.5), "float8"},
+		{"LenientBool", NewLenientBool(true), "bool"},
+		{"Nil LenientBool", NilLenientBool(), "bool"},
+		{"Int64Array", Int64Array{NewInt64(1), NilInt64()}, "_int8"},
+		{"StringArray", StringArray{NewString("a"), NilString()}, "_text"},
+		{"UUIDArray", UUIDArray{NewUUID(stubUUID)}, "_uuid"},
+		{"DateArray", DateArray{NewDate("2019-11-12")}, "_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pgtype.NewMap()
+			_, ok := m.TypeForValue(tt.give)
+			assert.False(t, ok, "registered before RegisterTypes")
+
+			RegisterTypes(m)
+			dt, ok := m.TypeForValue(tt.give)
+			if !assert.True(t, ok) {
+				return
+			}
+			assert.Equal(t, tt.want, dt.Name)
+
+			target := reflect.New(reflect.TypeOf(tt.give))
+			_, ok = m.TypeForValue(target.Interface())
+			assert.True(t, ok, "pointer not registered")
+
+			// With the OID unknown, the value is encoded and scanned by the
+			// codec of its registered type rather than through database/sql
+			for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+				buf, err := m.Encode(0, format, tt.give, []byte{})
+				assert.NoError(t, err)
+
+				plan := m.PlanScan(0, format, target.Interface())
+				assert.NotContains(t, fmt.Sprintf("%T", plan), "SQLScanner")
+				assert.NoError(t, plan.Scan(buf, target.Interface()))
+				if want, ok := tt.give.(Time); ok {
+					// the text and binary formats decode to different locations
+					assert.True(t, want.Time().Equal(target.Elem().Interface().(Time).Time()), "format %d", format)
+					continue
+				}
+				assert.Equal(t, tt.give, target.Elem().Interface(), "format %d", format)
+			}
+		})
+	}
+}
+
+func TestPgtype_ScanPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		oid    uint32
+		target any
+	}{
+		{"Bool", pgtype.BoolOID, &Bool{}},
+		{"Int32", pgtype.Int4OID, &Int32{}},
+		{"Int64", pgtype.Int8OID, &Int64{}},
+		{"Uint32", pgtype.Int8OID, &Uint32{}},
+		{"Float", pgtype.Float8OID, &Float{}},
+		{"String", pgtype.TextOID, &String{}},
+		{"UUID", pgtype.UUIDOID, &UUID{}},
+		{"Date", pgtype.DateOID, &Date{}},
+		{"Time", pgtype.TimestamptzOID, &Time{}},
+		{"Int64Array", pgtype.Int8ArrayOID, &Int64Array{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pgtype.NewMap()
+			RegisterTypes(m)
+			plan := m.PlanScan(tt.oid, pgtype.BinaryFormatCode, tt.target)
+			assert.NotContains(t, fmt.Sprintf("%T", plan), "SQLScanner")
+		})
+	}
+}
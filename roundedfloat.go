@@ -0,0 +1,168 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Precision describes how a RoundedFloat is rounded. Format returns a
+// strconv.FormatFloat format and precision: 'f' rounds to that many decimal
+// places and 'g' to that many significant digits. Define your own with an
+// empty struct, e.g.
+//
+//	type Places5 struct{}
+//
+//	func (Places5) Format() (byte, int) { return 'f', 5 }
+type Precision interface {
+	Format() (fmt byte, prec int)
+}
+
+// Places0 through Places4 round to a fixed number of decimal places
+type (
+	Places0 struct{}
+	Places1 struct{}
+	Places2 struct{}
+	Places3 struct{}
+	Places4 struct{}
+)
+
+// Format implements the Precision interface
+func (Places0) Format() (byte, int) { return 'f', 0 }
+
+// Format implements the Precision interface
+func (Places1) Format() (byte, int) { return 'f', 1 }
+
+// Format implements the Precision interface
+func (Places2) Format() (byte, int) { return 'f', 2 }
+
+// Format implements the Precision interface
+func (Places3) Format() (byte, int) { return 'f', 3 }
+
+// Format implements the Precision interface
+func (Places4) Format() (byte, int) { return 'f', 4 }
+
+// Digits3 through Digits6 round to a number of significant digits
+type (
+	Digits3 struct{}
+	Digits4 struct{}
+	Digits5 struct{}
+	Digits6 struct{}
+)
+
+// Format implements the Precision interface
+func (Digits3) Format() (byte, int) { return 'g', 3 }
+
+// Format implements the Precision interface
+func (Digits4) Format() (byte, int) { return 'g', 4 }
+
+// Format implements the Precision interface
+func (Digits5) Format() (byte, int) { return 'g', 5 }
+
+// Format implements the Precision interface
+func (Digits6) Format() (byte, int) { return 'g', 6 }
+
+// RoundValues wraps a Precision so that RoundedFloat also rounds the value it
+// passes to the database, e.g. RoundedFloat[RoundValues[Places2]]
+type RoundValues[P Precision] struct{}
+
+// Format implements the Precision interface
+func (RoundValues[P]) Format() (byte, int) {
+	var p P
+	return p.Format()
+}
+
+func (RoundValues[P]) roundValues() {}
+
+// RoundedFloat represents a nil-able float that is rounded to the precision P
+// by String and MarshalJSON, so that values like 0.30000000000000004 have a
+// stable serialized form. The unrounded value is kept, and is what Float and
+// Value return unless P is a RoundValues.
+type RoundedFloat[P Precision] struct {
+	baseFloat
+}
+
+// NewRoundedFloat makes a new non-nil RoundedFloat
+func NewRoundedFloat[P Precision](v float64) RoundedFloat[P] {
+	return RoundedFloat[P]{NewFloat(v)}
+}
+
+// NilRoundedFloat makes a new nil RoundedFloat
+func NilRoundedFloat[P Precision]() RoundedFloat[P] {
+	return RoundedFloat[P]{NilFloat()}
+}
+
+// Rounded returns the built-in float64 value rounded to the precision P
+func (v RoundedFloat[P]) Rounded() float64 {
+	if !v.Finite() {
+		return v.v
+	}
+	f, _ := strconv.ParseFloat(v.String(), 64)
+	return f
+}
+
+// String implements the fmt.Stringer interface
+func (v RoundedFloat[P]) String() string {
+	var p P
+	format, prec := p.Format()
+	s := strconv.FormatFloat(v.v, format, prec, 64)
+	if format == 'g' && v.Finite() {
+		// Round-trip to avoid exponents, e.g. 1.23e+06
+		f, _ := strconv.ParseFloat(s, 64)
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if len(s) > 1 && s[0] == '-' {
+		// Drop the sign of values that round to zero, e.g. -0.00
+		if f, _ := strconv.ParseFloat(s, 64); f == 0 {
+			s = s[1:]
+		}
+	}
+	return s
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v RoundedFloat[P]) MarshalJSON() ([]byte, error) {
+	if !v.initialized || !v.Finite() {
+		return v.baseFloat.MarshalJSON()
+	}
+	return []byte(v.String()), nil
+}
+
+// Value implements the driver.Valuer interface. The value is rounded if P is
+// a RoundValues.
+func (v RoundedFloat[P]) Value() (driver.Value, error) {
+	if v.present && v.roundValues() {
+		return v.Rounded(), nil
+	}
+	return v.baseFloat.Value()
+}
+
+// Float64Value implements the pgtype.Float64Valuer interface. The value is
+// rounded if P is a RoundValues.
+func (v RoundedFloat[P]) Float64Value() (pgtype.Float8, error) { //nolint:unparam
+	if v.roundValues() {
+		return pgtype.Float8{Float64: v.Rounded(), Valid: v.present}, nil
+	}
+	return v.baseFloat.Float64Value()
+}
+
+func (v RoundedFloat[P]) roundValues() bool {
+	var p P
+	_, ok := any(p).(interface{ roundValues() })
+	return ok
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubPlaces5 struct{}
+
+func (stubPlaces5) Format() (byte, int) { return 'f', 5 }
+
+func TestRoundedFloat_String(t *testing.T) {
+	tests := []struct {
+		name string
+		give interface{ String() string }
+		want string
+	}{
+		{"Places0", NewRoundedFloat[Places0](2.5), "2"},
+		{"Places1", NewRoundedFloat[Places1](0.1 + 0.2), "0.3"},
+		{"Places2", NewRoundedFloat[Places2](0.1 + 0.2), "0.30"},
+		{"Places2 Round Up", NewRoundedFloat[Places2](123.456), "123.46"},
+		{"Places2 Negative Zero", NewRoundedFloat[Places2](-0.001), "0.00"},
+		{"Places3", NewRoundedFloat[Places3](1), "1.000"},
+		{"Places4", NewRoundedFloat[Places4](math.Pi), "3.1416"},
+		{"Digits3", NewRoundedFloat[Digits3](1234567), "1230000"},
+		{"Digits3 Fraction", NewRoundedFloat[Digits3](0.1 + 0.2), "0.3"},
+		{"Digits4", NewRoundedFloat[Digits4](0.000123456), "0.0001235"},
+		{"Digits5", NewRoundedFloat[Digits5](math.Pi), "3.1416"},
+		{"Digits6", NewRoundedFloat[Digits6](-math.Pi), "-3.14159"},
+		{"Custom", NewRoundedFloat[stubPlaces5](math.Pi), "3.14159"},
+		{"RoundValues", NewRoundedFloat[RoundValues[Places1]](math.Pi), "3.1"},
+		{"NaN", NewRoundedFloat[Places2](math.NaN()), "NaN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give.String())
+		})
+	}
+}
+
+func TestRoundedFloat_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    json.Marshaler
+		want    string
+		wantErr bool
+	}{
+		{"Uninitialized", RoundedFloat[Places2]{}, `null`, false},
+		{"Nil", NilRoundedFloat[Places2](), `null`, false},
+		{"Places2", NewRoundedFloat[Places2](0.1 + 0.2), `0.30`, false},
+		{"Digits3", NewRoundedFloat[Digits3](1234567), `1230000`, false},
+		{"Inf", NewRoundedFloat[Places2](math.Inf(1)), ``, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRoundedFloat_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give driver.Valuer
+		want driver.Value
+	}{
+		{"Unrounded", NewRoundedFloat[Places2](math.Pi), math.Pi},
+		{"Rounded", NewRoundedFloat[RoundValues[Places2]](math.Pi), 3.14},
+		{"Rounded Digits", NewRoundedFloat[RoundValues[Digits3]](1234567), float64(1230000)},
+		{"Nil", NilRoundedFloat[RoundValues[Places2]](), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := NewRoundedFloat[RoundValues[Places2]](math.Pi).Float64Value()
+	assert.NoError(t, err)
+	assert.Equal(t, pgtype.Float8{Float64: 3.14, Valid: true}, got)
+
+	got, err = NewRoundedFloat[Places2](math.Pi).Float64Value()
+	assert.NoError(t, err)
+	assert.Equal(t, pgtype.Float8{Float64: math.Pi, Valid: true}, got)
+}
+
+func TestRoundedFloat_Float(t *testing.T) {
+	v := NewRoundedFloat[Places2](0.1 + 0.2)
+	assert.Equal(t, 0.1+0.2, v.Float())
+	assert.Equal(t, 0.3, v.Rounded())
+	assert.Equal(t, 0.0, NilRoundedFloat[Places2]().Float())
+}
+
+func TestRoundedFloat_Struct(t *testing.T) {
+	type listing struct {
+		PricePerSqft RoundedFloat[Places2] `json:"price_per_sqft"`
+		Ratio        RoundedFloat[Digits3] `json:"ratio"`
+	}
+
+	var got listing
+	assert.NoError(t, json.Unmarshal([]byte(`{"price_per_sqft":312.456789,"ratio":null}`), &got))
+	assert.Equal(t, 312.456789, got.PricePerSqft.Float())
+	assert.Equal(t, 312.46, got.PricePerSqft.Rounded())
+	assert.Equal(t, StateNull, got.Ratio.State())
+
+	b, err := json.Marshal(got)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price_per_sqft":312.46,"ratio":null}`, string(b))
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlbuilder builds parameterized Postgres statements from structs of
+// nillable fields tagged with their column names, e.g.
+//
+//	type ListingPatch struct {
+//		Price nillabletypes.Int64  `db:"price"`
+//		Name  nillabletypes.String `db:"name"`
+//	}
+//
+// Fields may also be pointers to nillable types, where a nil pointer is
+// treated as unset. Fields without a db tag, or tagged "-", are skipped. Where
+// also accepts slices of nillable fields, which become IN lists. Embedded
+// structs are walked as if their fields belonged to the outer struct.
+package sqlbuilder
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"github.com/housecanary/nillabletypes"
+	"github.com/pkg/errors"
+)
+
+type nillable interface {
+	driver.Valuer
+	State() nillabletypes.State
+}
+
+var nillableType = reflect.TypeOf((*nillable)(nil)).Elem()
+
+type column struct {
+	name string
+	v    nillable
+
+	// list holds the elements of a slice-of-nillable field, which is nil if
+	// the field is a single value or a nil slice
+	list   []nillable
+	isList bool
+}
+
+// columns returns the tagged nillable fields of v in declaration order
+func columns(v any) ([]column, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.Errorf("sqlbuilder: nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.Errorf("sqlbuilder: expected a struct, got %T", v)
+	}
+	var cols []column
+	return cols, walk(rv, &cols)
+}
+
+func walk(rv reflect.Value, cols *[]column) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("db") == "" {
+			if err := walk(rv.Field(i), cols); err != nil {
+				return err
+			}
+			continue
+		}
+		name := f.Tag.Get("db")
+		if name == "" || name == "-" || !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		switch {
+		case isNillable(f.Type):
+			*cols = append(*cols, column{name: name, v: nillableOf(fv)})
+		case f.Type.Kind() == reflect.Slice && isNillable(f.Type.Elem()):
+			c := column{name: name, isList: true}
+			if !fv.IsNil() {
+				c.list = make([]nillable, fv.Len())
+				for j := range c.list {
+					c.list[j] = nillableOf(fv.Index(j))
+				}
+			}
+			*cols = append(*cols, c)
+		default:
+			return errors.Errorf("sqlbuilder: field %s (%s) is not a nillable type", f.Name, name)
+		}
+	}
+	return nil
+}
+
+// isNillable returns whether t is a nillable type or a pointer to one
+func isNillable(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Implements(nillableType)
+}
+
+// nillableOf returns the nillable value of v, dereferencing a pointer. A nil
+// pointer is treated as unset.
+func nillableOf(v reflect.Value) nillable {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem()).Interface().(nillable)
+		}
+		v = v.Elem()
+	}
+	return v.Interface().(nillable)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlbuilder
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/housecanary/nillabletypes"
+	"github.com/pkg/errors"
+)
+
+// ErrNothingToUpdate is returned by Update when none of the fields are set
+var ErrNothingToUpdate = errors.New("sqlbuilder: no fields to update")
+
+// Update builds an UPDATE statement for table that sets only the initialized
+// fields of patch. Nil fields are set to NULL, and non-nil fields are bound to
+// the result of their Value method.
+//
+// The where clause may refer to whereArgs as $1..$n; the SET placeholders are
+// numbered after them, so
+//
+//	Update("listings", patch, "id = $1", id)
+//
+// builds "UPDATE listings SET price = $2, name = NULL WHERE id = $1" with the
+// arguments [id, price]. An empty where clause updates every row.
+func Update(table string, patch any, where string, whereArgs ...any) (string, []any, error) {
+	cols, err := columns(patch)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := append([]any(nil), whereArgs...)
+	var sb strings.Builder
+	sb.WriteString("UPDATE ")
+	sb.WriteString(table)
+	sb.WriteString(" SET ")
+	n := 0
+	for _, c := range cols {
+		if c.isList {
+			return "", nil, errors.Errorf("sqlbuilder: cannot update column %s from a list", c.name)
+		}
+		switch c.v.State() {
+		case nillabletypes.StateUnset:
+			continue
+		case nillabletypes.StateNull:
+			if n > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(c.name)
+			sb.WriteString(" = NULL")
+		case nillabletypes.StateValue:
+			v, err := c.v.Value()
+			if err != nil {
+				return "", nil, errors.Wrapf(err, "sqlbuilder: value of %s", c.name)
+			}
+			args = append(args, v)
+			if n > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(c.name)
+			sb.WriteString(" = $")
+			sb.WriteString(strconv.Itoa(len(args)))
+		}
+		n++
+	}
+	if n == 0 {
+		return "", nil, ErrNothingToUpdate
+	}
+	if where != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(where)
+	}
+	return sb.String(), args, nil
+}
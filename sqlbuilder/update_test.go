@@ -0,0 +1,138 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlbuilder
+
+import (
+	"testing"
+	"time"
+
+	nt "github.com/housecanary/nillabletypes"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAudit struct {
+	Updated nt.Time `db:"updated_at"`
+}
+
+type stubListingPatch struct {
+	stubAudit
+	Price    nt.Int64  `db:"price"`
+	Name     nt.String `db:"name"`
+	Active   nt.Bool   `db:"active"`
+	Listed   nt.Date   `db:"listed_on"`
+	Ignored  nt.String `db:"-"`
+	Untagged nt.String
+	Plain    string
+}
+
+func TestUpdate(t *testing.T) {
+	updated := time.Date(2019, 11, 12, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		give      string
+		where     string
+		whereArgs []any
+		wantSQL   string
+		wantArgs  []any
+		wantErr   error
+	}{
+		{
+			name:      "Values",
+			give:      `{"Price":500000,"Name":"Main St"}`,
+			where:     "id = $1",
+			whereArgs: []any{7},
+			wantSQL:   "UPDATE listings SET price = $2, name = $3 WHERE id = $1",
+			wantArgs:  []any{7, int64(500000), "Main St"},
+		},
+		{
+			name:     "Nulls",
+			give:     `{"Name":null,"Active":false,"Listed":null}`,
+			wantSQL:  "UPDATE listings SET name = NULL, active = $1, listed_on = NULL",
+			wantArgs: []any{false},
+		},
+		{
+			name:     "Embedded",
+			give:     `{"Updated":"2019-11-12T10:00:00Z","Price":null}`,
+			wantSQL:  "UPDATE listings SET updated_at = $1, price = NULL",
+			wantArgs: []any{updated},
+		},
+		{
+			name:    "Skipped Fields",
+			give:    `{"Ignored":"x","Untagged":"y","Plain":"z"}`,
+			wantErr: ErrNothingToUpdate,
+		},
+		{
+			name:    "Nothing",
+			give:    `{}`,
+			wantErr: ErrNothingToUpdate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch stubListingPatch
+			assert.NoError(t, json.Unmarshal([]byte(tt.give), &patch))
+
+			sql, args, err := Update("listings", &patch, tt.where, tt.whereArgs...)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUpdate_Errors(t *testing.T) {
+	_, _, err := Update("listings", "patch", "")
+	assert.Error(t, err)
+
+	_, _, err = Update("listings", (*stubListingPatch)(nil), "")
+	assert.Error(t, err)
+
+	_, _, err = Update("listings", struct {
+		Name string `db:"name"`
+	}{"x"}, "")
+	assert.Error(t, err)
+}
+
+func TestUpdate_List(t *testing.T) {
+	_, _, err := Update("listings", stubListingFilter{Status: []nt.String{nt.NewString("active")}}, "")
+	assert.Error(t, err)
+}
+
+func TestUpdate_Pointer(t *testing.T) {
+	type pointerPatch struct {
+		Price *nt.Int64  `db:"price"`
+		Name  *nt.String `db:"name"`
+	}
+
+	name := nt.NewString("Main St")
+	sql, args, err := Update("listings", pointerPatch{Name: &name}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE listings SET name = $1", sql)
+	assert.Equal(t, []any{"Main St"}, args)
+
+	price := nt.NilInt64()
+	sql, args, err = Update("listings", pointerPatch{Price: &price}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE listings SET price = NULL", sql)
+	assert.Empty(t, args)
+
+	_, _, err = Update("listings", pointerPatch{}, "")
+	assert.ErrorIs(t, err, ErrNothingToUpdate)
+}
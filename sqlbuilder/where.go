@@ -0,0 +1,111 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlbuilder
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/housecanary/nillabletypes"
+	"github.com/pkg/errors"
+)
+
+// Where builds a NULL-aware WHERE clause (without the WHERE keyword) from the
+// fields of filter, joined with AND:
+//
+//   - an unset field adds no predicate
+//   - a nil field becomes "col IS NULL"
+//   - a non-nil field becomes "col = $n", bound to the result of its Value
+//     method
+//
+// A slice of nillable values becomes "col IN ($n, ...)", with "OR col IS NULL"
+// added if the slice contains nils; unset elements are skipped. A nil slice
+// adds no predicate, and an empty one matches no rows.
+//
+// Placeholders are numbered after the start arguments already bound by the
+// caller, e.g. Where(filter, 1) starts at $2. If no predicates are added the
+// clause is "TRUE".
+func Where(filter any, start int) (string, []any, error) {
+	cols, err := columns(filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		preds []string
+		args  []any
+	)
+	placeholder := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(start+len(args))
+	}
+	for _, c := range cols {
+		if c.isList {
+			if c.list == nil {
+				continue
+			}
+			p, err := in(c.name, c.list, placeholder)
+			if err != nil {
+				return "", nil, err
+			}
+			preds = append(preds, p)
+			continue
+		}
+		switch c.v.State() {
+		case nillabletypes.StateNull:
+			preds = append(preds, c.name+" IS NULL")
+		case nillabletypes.StateValue:
+			v, err := c.v.Value()
+			if err != nil {
+				return "", nil, errors.Wrapf(err, "sqlbuilder: value of %s", c.name)
+			}
+			preds = append(preds, c.name+" = "+placeholder(v))
+		}
+	}
+	if len(preds) == 0 {
+		return "TRUE", nil, nil
+	}
+	return strings.Join(preds, " AND "), args, nil
+}
+
+func in(name string, list []nillable, placeholder func(any) string) (string, error) {
+	var (
+		ps      []string
+		hasNull bool
+	)
+	for _, e := range list {
+		switch e.State() {
+		case nillabletypes.StateUnset:
+			continue
+		case nillabletypes.StateNull:
+			hasNull = true
+			continue
+		}
+		v, err := e.Value()
+		if err != nil {
+			return "", errors.Wrapf(err, "sqlbuilder: value of %s", name)
+		}
+		ps = append(ps, placeholder(v))
+	}
+	switch {
+	case len(ps) == 0 && hasNull:
+		return name + " IS NULL", nil
+	case len(ps) == 0:
+		return "FALSE", nil
+	case hasNull:
+		return "(" + name + " IN (" + strings.Join(ps, ", ") + ") OR " + name + " IS NULL)", nil
+	}
+	return name + " IN (" + strings.Join(ps, ", ") + ")", nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlbuilder
+
+import (
+	"testing"
+
+	nt "github.com/housecanary/nillabletypes"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubListingFilter struct {
+	Price   nt.Int64    `db:"price"`
+	Name    nt.String   `db:"name"`
+	Status  []nt.String `db:"status"`
+	Ignored nt.String   `db:"-"`
+}
+
+func TestWhere(t *testing.T) {
+	tests := []struct {
+		name     string
+		give     stubListingFilter
+		start    int
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:    "Empty",
+			give:    stubListingFilter{},
+			wantSQL: "TRUE",
+		},
+		{
+			name:     "Values",
+			give:     stubListingFilter{Price: nt.NewInt64(10), Name: nt.NewString("Main St")},
+			wantSQL:  "price = $1 AND name = $2",
+			wantArgs: []any{int64(10), "Main St"},
+		},
+		{
+			name:     "Nil",
+			give:     stubListingFilter{Price: nt.NilInt64(), Name: nt.NewString("Main St")},
+			wantSQL:  "price IS NULL AND name = $1",
+			wantArgs: []any{"Main St"},
+		},
+		{
+			name:     "Start",
+			give:     stubListingFilter{Price: nt.NewInt64(10), Name: nt.NilString()},
+			start:    2,
+			wantSQL:  "price = $3 AND name IS NULL",
+			wantArgs: []any{int64(10)},
+		},
+		{
+			name:     "In",
+			give:     stubListingFilter{Status: []nt.String{nt.NewString("active"), nt.NewString("pending")}},
+			wantSQL:  "status IN ($1, $2)",
+			wantArgs: []any{"active", "pending"},
+		},
+		{
+			name:     "In With Nil",
+			give:     stubListingFilter{Price: nt.NewInt64(10), Status: []nt.String{nt.NewString("active"), nt.NilString(), {}}},
+			wantSQL:  "price = $1 AND (status IN ($2) OR status IS NULL)",
+			wantArgs: []any{int64(10), "active"},
+		},
+		{
+			name:    "In Only Nil",
+			give:    stubListingFilter{Status: []nt.String{nt.NilString()}},
+			wantSQL: "status IS NULL",
+		},
+		{
+			name:    "In Empty",
+			give:    stubListingFilter{Status: []nt.String{}},
+			wantSQL: "FALSE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := Where(tt.give, tt.start)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestWhere_Errors(t *testing.T) {
+	_, _, err := Where(42, 0)
+	assert.Error(t, err)
+
+	_, _, err = Where(struct {
+		IDs []int64 `db:"id"`
+	}{}, 0)
+	assert.Error(t, err)
+}
+
+func TestWhere_Pointer(t *testing.T) {
+	type pointerFilter struct {
+		Price  *nt.Int64    `db:"price"`
+		Name   *nt.String   `db:"name"`
+		Status []*nt.String `db:"status"`
+	}
+
+	sql, args, err := Where(pointerFilter{}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", sql)
+	assert.Empty(t, args)
+
+	name := nt.NilString()
+	active := nt.NewString("active")
+	sql, args, err = Where(pointerFilter{Name: &name, Status: []*nt.String{&active, nil}}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "name IS NULL AND status IN ($1)", sql)
+	assert.Equal(t, []any{"active"}, args)
+}
@@ -15,33 +15,49 @@
 package nillabletypes
 
 import (
-	"bytes"
 	"database/sql/driver"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
-	"github.com/segmentio/encoding/json"
 )
 
 // String represents a nil-able string
-type String struct {
-	v           string
-	present     bool
-	initialized bool
-}
+type String Nillable[string]
 
 // NewString makes a new non-nil String
 func NewString(v string) String {
-	return String{v: v, present: true, initialized: true}
+	return String(New(v))
 }
 
 // NilString makes a new nil String
 func NilString() String {
-	return String{"", false, true}
+	return String(Nil[string]())
 }
 
 // Nil returns whether this scalar is nil
 func (v String) Nil() bool {
-	return !v.present
+	return Nillable[string](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v String) Initialized() bool {
+	return Nillable[string](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v String) Set() bool {
+	return Nillable[string](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v String) State() State {
+	return Nillable[string](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *String) Reset() {
+	(*Nillable[string])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -51,34 +67,17 @@ func (v String) String() string {
 
 // UnmarshalJSON implements the json.Unmarshaler interface
 func (v *String) UnmarshalJSON(data []byte) error {
-	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
-		v.present = false
-		v.initialized = true
-		return nil
-	}
-	err := json.Unmarshal(data, &v.v)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	v.present = true
-	v.initialized = true
-	return nil
+	return (*Nillable[string])(v).UnmarshalJSON(data)
 }
 
 // MarshalJSON implements the json.Marshaler interface
 func (v String) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
-	}
-	return json.Marshal(v.v)
+	return Nillable[string](v).MarshalJSON()
 }
 
 // Value implements the driver.Valuer interface
 func (v String) Value() (driver.Value, error) {
-	if !v.present {
-		return nil, nil
-	}
-	return v.v, nil
+	return Nillable[string](v).Value()
 }
 
 // Scan implements the sql.Scanner interface
@@ -97,3 +96,14 @@ func (v *String) Scan(src interface{}) error {
 	}
 	return errors.Errorf("cannot scan value %v to a string", src)
 }
+
+// ScanText implements the pgtype.TextScanner interface
+func (v *String) ScanText(src pgtype.Text) error { //nolint:unparam
+	*v = String{v: src.String, present: src.Valid, initialized: true}
+	return nil
+}
+
+// TextValue implements the pgtype.TextValuer interface
+func (v String) TextValue() (pgtype.Text, error) { //nolint:unparam
+	return pgtype.Text{String: v.v, Valid: v.present}, nil
+}
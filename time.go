@@ -1,16 +1,72 @@
 package nillabletypes
 
 import (
-	"bytes"
 	"database/sql/driver"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
 )
 
+// DefaultInfiniteTimeJSON and DefaultNegInfiniteTimeJSON are the JSON strings
+// that infinite Times marshal to and unmarshal from unless changed with
+// SetInfiniteTimeJSON
+const (
+	DefaultInfiniteTimeJSON    = "infinity"
+	DefaultNegInfiniteTimeJSON = "-infinity"
+)
+
+type infiniteTimeJSON struct {
+	inf, negInf string
+}
+
+var defaultInfiniteTimeJSON atomic.Pointer[infiniteTimeJSON]
+
+// SetInfiniteTimeJSON sets the JSON strings that infinite and negative
+// infinite Times marshal to and unmarshal from, e.g. far-future and far-past
+// timestamps for clients that can't parse 'infinity'
+func SetInfiniteTimeJSON(inf, negInf string) {
+	defaultInfiniteTimeJSON.Store(&infiniteTimeJSON{inf: inf, negInf: negInf})
+}
+
+// CurrentInfiniteTimeJSON returns the JSON strings set with
+// SetInfiniteTimeJSON
+func CurrentInfiniteTimeJSON() (inf, negInf string) {
+	if j := defaultInfiniteTimeJSON.Load(); j != nil {
+		return j.inf, j.negInf
+	}
+	return DefaultInfiniteTimeJSON, DefaultNegInfiniteTimeJSON
+}
+
+// sqliteTimeLayout is the layout of the DATETIME text Times are valued as
+// under the SQLite dialect
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// timeTextLayouts are the layouts of text that Times are scanned from: RFC
+// 3339 and ISO 8601 timestamps, PostgreSQL's timestamp and timestamptz output,
+// whose offsets may be abbreviated to hours (e.g. "+00") or include seconds,
+// and MySQL and SQLite DATETIME text. Fractional seconds are accepted after
+// the seconds of any of them. Text without an offset is taken to be UTC.
+var timeTextLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05Z07",
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05Z0700",
+	"2006-01-02 15:04:05Z07:00:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
 type Time struct {
 	v           time.Time
+	inf         pgtype.InfinityModifier
 	present     bool
 	initialized bool
 }
@@ -23,47 +79,135 @@ func NilTime() Time {
 	return Time{present: false, initialized: true}
 }
 
-// Value implements the driver.Valuer interface
-func (v Time) Value() (driver.Value, error) { //nolint:unparam
-	if !v.present {
-		return nil, nil
+// NewInfiniteTime makes a new Time later than all other times, matching
+// PostgreSQL's 'infinity'
+func NewInfiniteTime() Time {
+	return Time{inf: pgtype.Infinity, present: true, initialized: true}
+}
+
+// NewNegInfiniteTime makes a new Time earlier than all other times, matching
+// PostgreSQL's '-infinity'
+func NewNegInfiniteTime() Time {
+	return Time{inf: pgtype.NegativeInfinity, present: true, initialized: true}
+}
+
+// finite returns the Nillable holding this Time's finite value
+func (v Time) finite() Nillable[time.Time] {
+	return Nillable[time.Time]{v: v.v, present: v.present, initialized: v.initialized}
+}
+
+// Value implements the driver.Valuer interface. Infinite Times are valued as
+// PostgreSQL's 'infinity' and '-infinity'. Under the SQLite dialect Times are
+// valued as text.
+func (v Time) Value() (driver.Value, error) {
+	return v.value(CurrentDialect())
+}
+
+func (v Time) value(d Dialect) (driver.Value, error) {
+	switch {
+	case d != Postgres && (v.IsInfinite() || v.IsNegInfinite()):
+		return nil, errors.Errorf("%v has no representation for an infinite time", d)
+	case d == SQLite && v.present:
+		return v.v.Format(sqliteTimeLayout), nil
+	case v.IsInfinite():
+		return "infinity", nil
+	case v.IsNegInfinite():
+		return "-infinity", nil
 	}
-	return v.v, nil
+	return v.finite().Value()
 }
 
+// Time returns the built-in time.Time value, which is the zero time if this
+// Time is nil or infinite
 func (v Time) Time() time.Time {
 	return v.v
 }
 
 func (v Time) Nil() bool {
-	return !v.present
+	return v.finite().Nil()
+}
+
+// IsInfinite returns whether this Time is 'infinity'
+func (v Time) IsInfinite() bool {
+	return v.present && v.inf == pgtype.Infinity
+}
+
+// IsNegInfinite returns whether this Time is '-infinity'
+func (v Time) IsNegInfinite() bool {
+	return v.present && v.inf == pgtype.NegativeInfinity
 }
 
-// UnmarshalJSON implements json.Unmarshaler
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Time) Initialized() bool {
+	return v.finite().Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Time) Set() bool {
+	return v.finite().Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Time) State() State {
+	return v.finite().State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Time) Reset() {
+	*v = Time{}
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The strings set with
+// SetInfiniteTimeJSON unmarshal to infinite Times.
 func (v *Time) UnmarshalJSON(data []byte) error {
-	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
-		v.present = false
-		v.initialized = true
-		return nil
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.WithStack(err)
+		}
+		inf, negInf := CurrentInfiniteTimeJSON()
+		switch s {
+		case inf:
+			*v = NewInfiniteTime()
+			return nil
+		case negInf:
+			*v = NewNegInfiniteTime()
+			return nil
+		}
 	}
-	if err := v.v.UnmarshalJSON(data); err != nil {
-		return errors.WithStack(err)
+	n := v.finite()
+	if err := n.UnmarshalJSON(data); err != nil {
+		return err
 	}
-	v.present = true
-	v.initialized = true
+	*v = Time{v: n.v, present: n.present, initialized: n.initialized}
 	return nil
 }
 
-// MarshalJSON implements json.Marshaler
+// MarshalJSON implements json.Marshaler. Infinite Times marshal to the
+// strings set with SetInfiniteTimeJSON.
 func (v Time) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
+	if v.initialized {
+		inf, negInf := CurrentInfiniteTimeJSON()
+		switch {
+		case v.IsInfinite():
+			return json.Marshal(inf)
+		case v.IsNegInfinite():
+			return json.Marshal(negInf)
+		}
 	}
-	return v.v.MarshalJSON()
+	return v.finite().MarshalJSON()
 }
 
-// Scan implements sql.Scanner
+// Scan implements sql.Scanner. It also accepts text, such as that of a
+// ::text cast or of a SQLite DATETIME column, in RFC 3339, ISO 8601 and
+// PostgreSQL timestamp formats. Text that can't be parsed returns a
+// *ParseError.
 func (v *Time) Scan(src any) error {
+	return v.scan(CurrentDialect(), src)
+}
+
+func (v *Time) scan(_ Dialect, src any) error {
 	switch t := src.(type) {
 	case nil:
 		*v = Time{present: false, initialized: true}
@@ -75,27 +219,55 @@ func (v *Time) Scan(src any) error {
 		} else {
 			*v = Time{v: *t, present: true, initialized: true}
 		}
+	case string:
+		return v.scanString(t)
+	case []byte:
+		return v.scanString(string(t))
 	default:
-		return errors.Errorf("cannot scan value %v to a time", src)
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to a time", src)
 	}
 
 	return nil
 }
 
+func (v *Time) scanString(s string) error {
+	switch s {
+	case "infinity":
+		*v = NewInfiniteTime()
+		return nil
+	case "-infinity":
+		*v = NewNegInfiniteTime()
+		return nil
+	}
+	for _, layout := range timeTextLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			*v = Time{v: t, present: true, initialized: true}
+			return nil
+		}
+	}
+	return &ParseError{Type: "time", Input: s, Err: errors.New("not an RFC 3339, ISO 8601 or PostgreSQL timestamp")}
+}
+
 func (v *Time) ScanTimestamp(src pgtype.Timestamp) error { //nolint:unparam
-	*v = Time{v: src.Time, present: src.Valid, initialized: true}
+	*v = Time{v: src.Time, inf: src.InfinityModifier, present: src.Valid, initialized: true}
 	return nil
 }
 
 func (v *Time) ScanTimestamptz(src pgtype.Timestamptz) error { //nolint:unparam
-	*v = Time{v: src.Time, present: src.Valid, initialized: true}
+	*v = Time{v: src.Time, inf: src.InfinityModifier, present: src.Valid, initialized: true}
+	return nil
+}
+
+// ScanDate implements the pgtype.DateScanner interface
+func (v *Time) ScanDate(src pgtype.Date) error { //nolint:unparam
+	*v = Time{v: src.Time, inf: src.InfinityModifier, present: src.Valid, initialized: true}
 	return nil
 }
 
 func (v Time) TimestampValue() (pgtype.Timestamp, error) { //nolint:unparam
-	return pgtype.Timestamp{Time: v.v, Valid: v.present}, nil
+	return pgtype.Timestamp{Time: v.v, InfinityModifier: v.inf, Valid: v.present}, nil
 }
 
 func (v Time) TimestamptzValue() (pgtype.Timestamptz, error) { //nolint:unparam
-	return pgtype.Timestamptz{Time: v.v, Valid: v.present}, nil
+	return pgtype.Timestamptz{Time: v.v, InfinityModifier: v.inf, Valid: v.present}, nil
 }
@@ -0,0 +1,227 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+var stubTime = time.Date(2019, 11, 12, 10, 30, 0, 0, time.UTC)
+
+func TestTime_IsInfinite(t *testing.T) {
+	tests := []struct {
+		name       string
+		give       Time
+		wantInf    bool
+		wantNegInf bool
+		wantNil    bool
+	}{
+		{"Nil", NilTime(), false, false, true},
+		{"Finite", NewTime(stubTime), false, false, false},
+		{"Infinity", NewInfiniteTime(), true, false, false},
+		{"Negative Infinity", NewNegInfiniteTime(), false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantInf, tt.give.IsInfinite())
+			assert.Equal(t, tt.wantNegInf, tt.give.IsNegInfinite())
+			assert.Equal(t, tt.wantNil, tt.give.Nil())
+		})
+	}
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    Time
+		wantErr bool
+	}{
+		{
+			name: "Time",
+			give: toJSONBytes("2019-11-12T10:30:00Z"),
+			want: NewTime(stubTime),
+		},
+		{
+			name: "Infinity",
+			give: toJSONBytes("infinity"),
+			want: NewInfiniteTime(),
+		},
+		{
+			name: "Negative Infinity",
+			give: toJSONBytes("-infinity"),
+			want: NewNegInfiniteTime(),
+		},
+		{
+			name: "Null",
+			give: toJSONBytes(nil),
+			want: NilTime(),
+		},
+		{
+			name:    "Invalid",
+			give:    toJSONBytes("tomorrow"),
+			wantErr: true,
+		},
+		{
+			name:    "Number",
+			give:    toJSONBytes(12),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Time{}
+			err := got.UnmarshalJSON(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Time
+		want []byte
+	}{
+		{"Uninitialized", Time{}, toJSONBytes(nil)},
+		{"Nil", NilTime(), toJSONBytes(nil)},
+		{"Time", NewTime(stubTime), toJSONBytes("2019-11-12T10:30:00Z")},
+		{"Infinity", NewInfiniteTime(), toJSONBytes("infinity")},
+		{"Negative Infinity", NewNegInfiniteTime(), toJSONBytes("-infinity")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTime_JSONSentinel(t *testing.T) {
+	defer SetInfiniteTimeJSON(CurrentInfiniteTimeJSON())
+	SetInfiniteTimeJSON("9999-12-31T23:59:59Z", "0001-01-01T00:00:00Z")
+
+	inf, negInf := CurrentInfiniteTimeJSON()
+	assert.Equal(t, "9999-12-31T23:59:59Z", inf)
+	assert.Equal(t, "0001-01-01T00:00:00Z", negInf)
+
+	got, err := NewInfiniteTime().MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, toJSONBytes("9999-12-31T23:59:59Z"), got)
+
+	var v Time
+	assert.NoError(t, v.UnmarshalJSON(toJSONBytes("0001-01-01T00:00:00Z")))
+	assert.Equal(t, NewNegInfiniteTime(), v)
+}
+
+func TestTime_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Time
+		want driver.Value
+	}{
+		{"Nil", NilTime(), nil},
+		{"Time", NewTime(stubTime), stubTime},
+		{"Infinity", NewInfiniteTime(), "infinity"},
+		{"Negative Infinity", NewNegInfiniteTime(), "-infinity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTime_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Time
+		wantErr bool
+	}{
+		{"Nil", nil, NilTime(), false},
+		{"Time", stubTime, NewTime(stubTime), false},
+		{"Nil Time Pointer", (*time.Time)(nil), NilTime(), false},
+		{"Time Pointer", &stubTime, NewTime(stubTime), false},
+		{"Infinity", "infinity", NewInfiniteTime(), false},
+		{"Negative Infinity", []byte("-infinity"), NewNegInfiniteTime(), false},
+		{"RFC3339", "2019-11-12T10:30:00Z", NewTime(stubTime), false},
+		{"RFC3339 Fraction", "2019-11-12T10:30:00.123456789Z", NewTime(stubTime.Add(123456789)), false},
+		{"Timestamptz Text", []byte("2019-11-12 10:30:00+00"), NewTime(stubTime), false},
+		{"Timestamptz Text Fraction", "2019-11-12 15:00:00.5+04:30", NewTime(time.Date(2019, 11, 12, 15, 0, 0, 5e8, time.FixedZone("", 4*60*60+30*60))), false},
+		{"Timestamptz Text Offset Seconds", "1850-01-01 00:00:00-07:52:58", NewTime(time.Date(1850, 1, 1, 0, 0, 0, 0, time.FixedZone("", -(7*60*60+52*60+58)))), false},
+		{"ISO Basic Offset", "2019-11-12T03:30:00-0700", NewTime(time.Date(2019, 11, 12, 3, 30, 0, 0, time.FixedZone("", -7*60*60))), false},
+		{"Timestamp Text", "2019-11-12 10:30:00.000001", NewTime(stubTime.Add(time.Microsecond)), false},
+		{"Date Text", "2019-11-12", NewTime(time.Date(2019, 11, 12, 0, 0, 0, 0, time.UTC)), false},
+		{"Invalid Text", "11/12/2019 10:30", Time{}, true},
+		{"Int", int64(1), Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Time{}
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			if !tt.want.Nil() && !tt.want.IsInfinite() && !tt.want.IsNegInfinite() {
+				assert.True(t, tt.want.Time().Equal(got.Time()), "got %v", got.Time())
+				assert.Equal(t, tt.want.Time().Format(time.RFC3339Nano), got.Time().Format(time.RFC3339Nano))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTime_ScanParseError(t *testing.T) {
+	var got Time
+	err := got.Scan([]byte("2019-11-12 25:00:00"))
+	var pe *ParseError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, "time", pe.Type)
+		assert.Equal(t, "2019-11-12 25:00:00", pe.Input)
+	}
+}
+
+func TestTime_Timestamptz(t *testing.T) {
+	tests := []struct {
+		name string
+		give pgtype.Timestamptz
+		want Time
+	}{
+		{"Null", pgtype.Timestamptz{}, NilTime()},
+		{"Time", pgtype.Timestamptz{Time: stubTime, Valid: true}, NewTime(stubTime)},
+		{"Infinity", pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}, NewInfiniteTime()},
+		{"Negative Infinity", pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, NewNegInfiniteTime()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Time{}
+			assert.NoError(t, got.ScanTimestamptz(tt.give))
+			assert.Equal(t, tt.want, got)
+
+			value, err := got.TimestamptzValue()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.give, value)
+		})
+	}
+}
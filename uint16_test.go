@@ -0,0 +1,134 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUint16(t *testing.T) {
+	assert.Equal(t, Uint16{v: math.MaxUint16, present: true, initialized: true}, NewUint16(math.MaxUint16))
+	assert.Equal(t, Uint16{present: false, initialized: true}, NilUint16())
+	assert.Equal(t, uint16(math.MaxUint16), NewUint16(math.MaxUint16).Uint16())
+	assert.True(t, NilUint16().Nil())
+	assert.Equal(t, StateValue, NewUint16(0).State())
+
+	v := NewUint16(1)
+	v.Reset()
+	assert.Equal(t, Uint16{}, v)
+}
+
+func TestUint16_String(t *testing.T) {
+	assert.Equal(t, "65535", NewUint16(math.MaxUint16).String())
+	assert.Equal(t, "0", NewUint16(0).String())
+	assert.Equal(t, "0", NilUint16().String())
+}
+
+func TestUint16_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Uint16
+		wantErr bool
+	}{
+		{"Max", `65535`, NewUint16(math.MaxUint16), false},
+		{"Min", `0`, NewUint16(0), false},
+		{"Whole Floating Point Number", `3.0`, NewUint16(3), false},
+		{"Null", `null`, NilUint16(), false},
+		{"Above Max", `65536`, Uint16{}, true},
+		{"Below Min", `-1`, Uint16{}, true},
+		{"Fraction", `3.5`, Uint16{}, true},
+		{"String", `"3"`, Uint16{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint16
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint16_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint16
+		want string
+	}{
+		{"Value", NewUint16(math.MaxUint16), `65535`},
+		{"Nil", NilUint16(), `null`},
+		{"Uninitialized", Uint16{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestUint16_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint16
+		want driver.Value
+	}{
+		{"Nil", NilUint16(), nil},
+		{"Max", NewUint16(math.MaxUint16), int64(math.MaxUint16)},
+		{"Min", NewUint16(0), int64(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint16_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Uint16
+		wantErr bool
+	}{
+		{"Nil", nil, NilUint16(), false},
+		{"True", true, NewUint16(1), false},
+		{"Int Max", int64(math.MaxUint16), NewUint16(math.MaxUint16), false},
+		{"Int Above Max", int64(65536), Uint16{}, true},
+		{"Int Below Min", int64(-1), Uint16{}, true},
+		{"Float", 12.0, NewUint16(12), false},
+		{"Float Above Max", 65536.0, Uint16{}, true},
+		{"Byte Slice", []byte("65535"), NewUint16(math.MaxUint16), false},
+		{"String", "0", NewUint16(0), false},
+		{"String Above Max", "65536", Uint16{}, true},
+		{"Word", "twister", Uint16{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint16
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -20,25 +20,21 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
-	"github.com/segmentio/encoding/json"
 )
 
 // Uint32 represents a nil-able int
-type Uint32 struct {
-	v           uint32
-	present     bool
-	initialized bool
-}
+type Uint32 Nillable[uint32]
 
 // NewUint32 makes a new non-nil Uint32
 func NewUint32(v uint32) Uint32 {
-	return Uint32{v: v, present: true, initialized: true}
+	return Uint32(New(v))
 }
 
 // NilUint32 makes a new nil Uint32
 func NilUint32() Uint32 {
-	return Uint32{0, false, true}
+	return Uint32(Nil[uint32]())
 }
 
 // Uint32 returns the built-in Uint32 value
@@ -48,7 +44,28 @@ func (v Uint32) Uint32() uint32 {
 
 // Nil returns whether this scalar is nil
 func (v Uint32) Nil() bool {
-	return !v.present
+	return Nillable[uint32](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Uint32) Initialized() bool {
+	return Nillable[uint32](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Uint32) Set() bool {
+	return Nillable[uint32](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Uint32) State() State {
+	return Nillable[uint32](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Uint32) Reset() {
+	(*Nillable[uint32])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -63,13 +80,11 @@ func (v *Uint32) UnmarshalJSON(data []byte) error {
 		v.initialized = true
 		return nil
 	}
-	var t float64
-	err := json.Unmarshal(data, &t)
+	n, err := jsonToInt64(data)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
-
-	i, err := float64ToUint32(t)
+	i, err := int64ToUint32(n)
 	if err != nil {
 		return err
 	}
@@ -81,18 +96,15 @@ func (v *Uint32) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON implements the json.Marshaler interface
 func (v Uint32) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
-	}
-	return json.Marshal(v.v)
+	return Nillable[uint32](v).MarshalJSON()
 }
 
-// Value implements the driver.Valuer interface
-func (v Uint32) Value() (driver.Value, error) {
+// Value implements the driver.Valuer interface, returning the value as an int64
+func (v Uint32) Value() (driver.Value, error) { //nolint:unparam
 	if !v.present {
 		return nil, nil
 	}
-	return v.v, nil
+	return int64(v.v), nil
 }
 
 // Scan implements the sql.Scanner interface
@@ -146,6 +158,25 @@ func (v *Uint32) scanString(src string) error {
 	return nil
 }
 
+// ScanInt64 implements the pgtype.Int64Scanner interface
+func (v *Uint32) ScanInt64(src pgtype.Int8) error {
+	if !src.Valid {
+		*v = Uint32{present: false, initialized: true}
+		return nil
+	}
+	i, err := int64ToUint32(src.Int64)
+	if err != nil {
+		return err
+	}
+	*v = Uint32{v: i, present: true, initialized: true}
+	return nil
+}
+
+// Int64Value implements the pgtype.Int64Valuer interface
+func (v Uint32) Int64Value() (pgtype.Int8, error) { //nolint:unparam
+	return pgtype.Int8{Int64: int64(v.v), Valid: v.present}, nil
+}
+
 func int64ToUint32(i int64) (uint32, error) {
 	if int64(math.MaxUint32) < i || 0 > i {
 		return 0, errors.Errorf("value %v outside of the range of Uint32", i)
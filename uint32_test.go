@@ -172,9 +172,30 @@ func TestUint32_UnmarshalJSON(t *testing.T) {
 		{
 			name:    "Floating Point Number",
 			give:    toBytes(3.14159),
+			wantErr: true,
+		},
+		{
+			name:    "Whole Floating Point Number",
+			give:    []byte("3.0"),
 			want:    Uint32{v: 3, present: true, initialized: true},
 			wantErr: false,
 		},
+		{
+			name:    "Max",
+			give:    []byte("4294967295"),
+			want:    Uint32{v: math.MaxUint32, present: true, initialized: true},
+			wantErr: false,
+		},
+		{
+			name:    "Above Max",
+			give:    []byte("4294967296"),
+			wantErr: true,
+		},
+		{
+			name:    "Negative",
+			give:    []byte("-1"),
+			wantErr: true,
+		},
 		{
 			name:    "Floating Point Number (Max)",
 			give:    toBytes(float64(math.MaxUint32)),
@@ -274,7 +295,7 @@ func TestUint32_Value(t *testing.T) {
 		{
 			name:    "Not Nil",
 			give:    Uint32{v: 65, present: true, initialized: true},
-			want:    uint32(65),
+			want:    int64(65),
 			wantErr: false,
 		},
 	}
@@ -0,0 +1,210 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// Uint64 represents a nil-able uint64. PostgreSQL has no unsigned 64-bit
+// type, so it is stored as a numeric there.
+type Uint64 Nillable[uint64]
+
+// NewUint64 makes a new non-nil Uint64
+func NewUint64(v uint64) Uint64 {
+	return Uint64(New(v))
+}
+
+// NilUint64 makes a new nil Uint64
+func NilUint64() Uint64 {
+	return Uint64(Nil[uint64]())
+}
+
+// Uint64 returns the built-in uint64 value
+func (v Uint64) Uint64() uint64 {
+	return v.v
+}
+
+// Nil returns whether this scalar is nil
+func (v Uint64) Nil() bool {
+	return Nillable[uint64](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Uint64) Initialized() bool {
+	return Nillable[uint64](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Uint64) Set() bool {
+	return Nillable[uint64](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Uint64) State() State {
+	return Nillable[uint64](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Uint64) Reset() {
+	(*Nillable[uint64])(v).Reset()
+}
+
+// String implements the fmt.Stringer interface
+func (v Uint64) String() string {
+	return strconv.FormatUint(v.v, 10)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *Uint64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		v.present = false
+		v.initialized = true
+		return nil
+	}
+	n, s, err := jsonToBigInt(data)
+	if err != nil {
+		return err
+	}
+	if !n.IsUint64() {
+		return errors.Errorf("value %s outside of the range of Uint64", s)
+	}
+	v.v = n.Uint64()
+	v.present = true
+	v.initialized = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Uint64) MarshalJSON() ([]byte, error) {
+	return Nillable[uint64](v).MarshalJSON()
+}
+
+// Value implements the driver.Valuer interface. Values up to math.MaxInt64
+// are returned as an int64, and larger values as their decimal string, which
+// drivers pass on as text for the database to convert.
+func (v Uint64) Value() (driver.Value, error) { //nolint:unparam
+	if !v.present {
+		return nil, nil
+	}
+	if v.v > math.MaxInt64 {
+		return v.String(), nil
+	}
+	return int64(v.v), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Uint64) Scan(src interface{}) error {
+	if src == nil {
+		*v = Uint64{present: false, initialized: true}
+		return nil
+	}
+	switch t := src.(type) {
+	case int64:
+		if t < 0 {
+			return errors.Errorf("value %v outside of the range of Uint64", t)
+		}
+		*v = Uint64{v: uint64(t), present: true, initialized: true}
+		return nil
+	case float64:
+		val, err := float64ToUint64(t)
+		if err != nil {
+			return err
+		}
+		*v = Uint64{v: val, present: true, initialized: true}
+		return nil
+	case bool:
+		if t {
+			*v = Uint64{v: 1, present: true, initialized: true}
+		} else {
+			*v = Uint64{v: 0, present: true, initialized: true}
+		}
+		return nil
+	case []byte:
+		s := string(t)
+		return v.scanString(s)
+	case string:
+		return v.scanString(t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to a uint", src)
+}
+
+func (v *Uint64) scanString(src string) error {
+	// Parse integers exactly, since a float64 can't hold every uint64
+	if i, err := strconv.ParseUint(src, 10, 64); err == nil {
+		*v = Uint64{v: i, present: true, initialized: true}
+		return nil
+	}
+	f, err := strconv.ParseFloat(src, 64)
+	if err != nil {
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to a uint", src)
+	}
+	i, err := float64ToUint64(f)
+	if err != nil {
+		return err
+	}
+
+	*v = Uint64{v: i, present: true, initialized: true}
+	return nil
+}
+
+// ScanNumeric implements the pgtype.NumericScanner interface
+func (v *Uint64) ScanNumeric(src pgtype.Numeric) error {
+	if !src.Valid {
+		*v = Uint64{present: false, initialized: true}
+		return nil
+	}
+	if src.NaN || src.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan a non-finite numeric to a uint")
+	}
+	r := new(big.Rat)
+	if src.Int != nil {
+		r = NewDecimalFromBigInt(src.Int, -src.Exp).Rat()
+	}
+	if !r.IsInt() || !r.Num().IsUint64() {
+		return errors.Errorf("value %v outside of the range of Uint64", r.RatString())
+	}
+	*v = Uint64{v: r.Num().Uint64(), present: true, initialized: true}
+	return nil
+}
+
+// NumericValue implements the pgtype.NumericValuer interface
+func (v Uint64) NumericValue() (pgtype.Numeric, error) { //nolint:unparam
+	if !v.present {
+		return pgtype.Numeric{}, nil
+	}
+	return pgtype.Numeric{Int: new(big.Int).SetUint64(v.v), Valid: true}, nil
+}
+
+func float64ToUint64(f float64) (uint64, error) {
+	// Converting a float64 outside of the range of uint64 is undefined, so
+	// check the range first
+	if f <= -1 || f >= math.MaxUint64 {
+		return 0, errors.Errorf("value %f outside of the range of Uint64", f)
+	}
+	val := uint64(f)
+	if math.Trunc(f) != float64(val) {
+		return 0, errors.Errorf("value %f outside of the range of Uint64", f)
+	}
+	return val, nil
+}
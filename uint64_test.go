@@ -0,0 +1,161 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUint64(t *testing.T) {
+	assert.Equal(t, Uint64{v: math.MaxUint64, present: true, initialized: true}, NewUint64(math.MaxUint64))
+	assert.Equal(t, Uint64{present: false, initialized: true}, NilUint64())
+	assert.Equal(t, uint64(math.MaxUint64), NewUint64(math.MaxUint64).Uint64())
+	assert.True(t, NilUint64().Nil())
+	assert.Equal(t, StateValue, NewUint64(0).State())
+
+	v := NewUint64(1)
+	v.Reset()
+	assert.Equal(t, Uint64{}, v)
+}
+
+func TestUint64_String(t *testing.T) {
+	assert.Equal(t, "18446744073709551615", NewUint64(math.MaxUint64).String())
+	assert.Equal(t, "0", NilUint64().String())
+}
+
+func TestUint64_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Uint64
+		wantErr bool
+	}{
+		{"Max", `18446744073709551615`, NewUint64(math.MaxUint64), false},
+		{"Above MaxInt64", `9223372036854775808`, NewUint64(math.MaxInt64 + 1), false},
+		{"Exponent", `1.8e19`, NewUint64(18000000000000000000), false},
+		{"Whole Floating Point Number", `3.0`, NewUint64(3), false},
+		{"Null", `null`, NilUint64(), false},
+		{"Above Max", `18446744073709551616`, Uint64{}, true},
+		{"Negative", `-1`, Uint64{}, true},
+		{"Fraction", `3.5`, Uint64{}, true},
+		{"Underflowing Fraction", `1e-400`, Uint64{}, true},
+		{"String", `"3"`, Uint64{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint64
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint64_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint64
+		want string
+	}{
+		{"Value", NewUint64(math.MaxUint64), `18446744073709551615`},
+		{"Nil", NilUint64(), `null`},
+		{"Uninitialized", Uint64{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestUint64_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint64
+		want driver.Value
+	}{
+		{"Nil", NilUint64(), nil},
+		{"MaxInt64", NewUint64(math.MaxInt64), int64(math.MaxInt64)},
+		{"Above MaxInt64", NewUint64(math.MaxInt64 + 1), "9223372036854775808"},
+		{"Max", NewUint64(math.MaxUint64), "18446744073709551615"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint64_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Uint64
+		wantErr bool
+	}{
+		{"Nil", nil, NilUint64(), false},
+		{"True", true, NewUint64(1), false},
+		{"Int Max", int64(math.MaxInt64), NewUint64(math.MaxInt64), false},
+		{"Int Below Min", int64(-1), Uint64{}, true},
+		{"Float", 12.0, NewUint64(12), false},
+		{"Float Above Max", 1.9e19, Uint64{}, true},
+		{"Byte Slice", []byte("18446744073709551615"), NewUint64(math.MaxUint64), false},
+		{"String", "0", NewUint64(0), false},
+		{"String Above Max", "18446744073709551616", Uint64{}, true},
+		{"Word", "twister", Uint64{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint64
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint64_ScanNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    pgtype.Numeric
+		want    Uint64
+		wantErr bool
+	}{
+		{"Nil", pgtype.Numeric{}, NilUint64(), false},
+		{"Max", pgtype.Numeric{Int: new(big.Int).SetUint64(math.MaxUint64), Valid: true}, NewUint64(math.MaxUint64), false},
+		{"Exponent", pgtype.Numeric{Int: big.NewInt(12), Exp: 2, Valid: true}, NewUint64(1200), false},
+		{"Fraction", pgtype.Numeric{Int: big.NewInt(12), Exp: -1, Valid: true}, Uint64{}, true},
+		{"Negative", pgtype.Numeric{Int: big.NewInt(-1), Valid: true}, Uint64{}, true},
+		{"NaN", pgtype.Numeric{NaN: true, Valid: true}, Uint64{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint64
+			err := got.ScanNumeric(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"math"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// Uint8 represents a nil-able uint8
+type Uint8 Nillable[uint8]
+
+// NewUint8 makes a new non-nil Uint8
+func NewUint8(v uint8) Uint8 {
+	return Uint8(New(v))
+}
+
+// NilUint8 makes a new nil Uint8
+func NilUint8() Uint8 {
+	return Uint8(Nil[uint8]())
+}
+
+// Uint8 returns the built-in Uint8 value
+func (v Uint8) Uint8() uint8 {
+	return v.v
+}
+
+// Nil returns whether this scalar is nil
+func (v Uint8) Nil() bool {
+	return Nillable[uint8](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v Uint8) Initialized() bool {
+	return Nillable[uint8](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v Uint8) Set() bool {
+	return Nillable[uint8](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v Uint8) State() State {
+	return Nillable[uint8](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *Uint8) Reset() {
+	(*Nillable[uint8])(v).Reset()
+}
+
+// String implements the fmt.Stringer interface
+func (v Uint8) String() string {
+	return strconv.FormatUint(uint64(v.v), 10)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *Uint8) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
+		v.present = false
+		v.initialized = true
+		return nil
+	}
+	n, err := jsonToInt64(data)
+	if err != nil {
+		return err
+	}
+	i, err := int64ToUint8(n)
+	if err != nil {
+		return err
+	}
+	v.v = i
+	v.present = true
+	v.initialized = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Uint8) MarshalJSON() ([]byte, error) {
+	return Nillable[uint8](v).MarshalJSON()
+}
+
+// Value implements the driver.Valuer interface, returning the value as an int64
+func (v Uint8) Value() (driver.Value, error) { //nolint:unparam
+	if !v.present {
+		return nil, nil
+	}
+	return int64(v.v), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Uint8) Scan(src interface{}) error {
+	if src == nil {
+		*v = Uint8{present: false, initialized: true}
+		return nil
+	}
+	switch t := src.(type) {
+	case int64:
+		i, err := int64ToUint8(t)
+		if err != nil {
+			return err
+		}
+		*v = Uint8{v: i, present: true, initialized: true}
+		return nil
+	case float64:
+		val, err := float64ToUint8(t)
+		if err != nil {
+			return err
+		}
+		*v = Uint8{v: val, present: true, initialized: true}
+		return nil
+	case bool:
+		if t {
+			*v = Uint8{v: 1, present: true, initialized: true}
+		} else {
+			*v = Uint8{v: 0, present: true, initialized: true}
+		}
+		return nil
+	case []byte:
+		s := string(t)
+		return v.scanString(s)
+	case string:
+		return v.scanString(t)
+	}
+	return errors.Errorf("cannot scan value %[1]v of type %[1]T to a uint", src)
+}
+
+func (v *Uint8) scanString(src string) error {
+	f, err := strconv.ParseFloat(src, 64)
+	if err != nil {
+		return errors.Errorf("cannot scan value %[1]v of type %[1]T to a uint", src)
+	}
+	i, err := float64ToUint8(f)
+	if err != nil {
+		return err
+	}
+
+	*v = Uint8{v: i, present: true, initialized: true}
+	return nil
+}
+
+// ScanInt64 implements the pgtype.Int64Scanner interface
+func (v *Uint8) ScanInt64(src pgtype.Int8) error {
+	if !src.Valid {
+		*v = Uint8{present: false, initialized: true}
+		return nil
+	}
+	i, err := int64ToUint8(src.Int64)
+	if err != nil {
+		return err
+	}
+	*v = Uint8{v: i, present: true, initialized: true}
+	return nil
+}
+
+// Int64Value implements the pgtype.Int64Valuer interface
+func (v Uint8) Int64Value() (pgtype.Int8, error) { //nolint:unparam
+	return pgtype.Int8{Int64: int64(v.v), Valid: v.present}, nil
+}
+
+func int64ToUint8(i int64) (uint8, error) {
+	if int64(math.MaxUint8) < i || 0 > i {
+		return 0, errors.Errorf("value %v outside of the range of Uint8", i)
+	}
+	return uint8(i), nil
+}
+
+func float64ToUint8(f float64) (uint8, error) {
+	val := uint8(f)
+	if math.Trunc(f) != float64(val) {
+		return 0, errors.Errorf("value %f outside of the range of Uint8", f)
+	}
+	return val, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 HouseCanary, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nillabletypes
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUint8(t *testing.T) {
+	assert.Equal(t, Uint8{v: math.MaxUint8, present: true, initialized: true}, NewUint8(math.MaxUint8))
+	assert.Equal(t, Uint8{present: false, initialized: true}, NilUint8())
+	assert.Equal(t, uint8(math.MaxUint8), NewUint8(math.MaxUint8).Uint8())
+	assert.True(t, NilUint8().Nil())
+	assert.Equal(t, StateValue, NewUint8(0).State())
+
+	v := NewUint8(1)
+	v.Reset()
+	assert.Equal(t, Uint8{}, v)
+}
+
+func TestUint8_String(t *testing.T) {
+	assert.Equal(t, "255", NewUint8(math.MaxUint8).String())
+	assert.Equal(t, "0", NewUint8(0).String())
+	assert.Equal(t, "0", NilUint8().String())
+}
+
+func TestUint8_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    Uint8
+		wantErr bool
+	}{
+		{"Max", `255`, NewUint8(math.MaxUint8), false},
+		{"Min", `0`, NewUint8(0), false},
+		{"Whole Floating Point Number", `3.0`, NewUint8(3), false},
+		{"Null", `null`, NilUint8(), false},
+		{"Above Max", `256`, Uint8{}, true},
+		{"Below Min", `-1`, Uint8{}, true},
+		{"Fraction", `3.5`, Uint8{}, true},
+		{"String", `"3"`, Uint8{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint8
+			err := got.UnmarshalJSON([]byte(tt.give))
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint8_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint8
+		want string
+	}{
+		{"Value", NewUint8(math.MaxUint8), `255`},
+		{"Nil", NilUint8(), `null`},
+		{"Uninitialized", Uint8{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestUint8_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		give Uint8
+		want driver.Value
+	}{
+		{"Nil", NilUint8(), nil},
+		{"Max", NewUint8(math.MaxUint8), int64(math.MaxUint8)},
+		{"Min", NewUint8(0), int64(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.give.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUint8_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    any
+		want    Uint8
+		wantErr bool
+	}{
+		{"Nil", nil, NilUint8(), false},
+		{"True", true, NewUint8(1), false},
+		{"Int Max", int64(math.MaxUint8), NewUint8(math.MaxUint8), false},
+		{"Int Above Max", int64(256), Uint8{}, true},
+		{"Int Below Min", int64(-1), Uint8{}, true},
+		{"Float", 12.0, NewUint8(12), false},
+		{"Float Above Max", 256.0, Uint8{}, true},
+		{"Byte Slice", []byte("255"), NewUint8(math.MaxUint8), false},
+		{"String", "0", NewUint8(0), false},
+		{"String Above Max", "256", Uint8{}, true},
+		{"Word", "twister", Uint8{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint8
+			err := got.Scan(tt.give)
+			assertWantError(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,35 +15,50 @@
 package nillabletypes
 
 import (
-	"bytes"
 	"database/sql/driver"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
-	"github.com/segmentio/encoding/json"
 )
 
 // UUID represents a nil-able UUID
-type UUID struct {
-	v           uuid.UUID
-	present     bool
-	initialized bool
-}
+type UUID Nillable[uuid.UUID]
 
 // NewUUID makes a new non-nil UUID
 func NewUUID(v uuid.UUID) UUID {
-	return UUID{v: v, present: true, initialized: true}
+	return UUID(New(v))
 }
 
 // NilUUID makes a new nil UUID
 func NilUUID() UUID {
-	return UUID{uuid.Nil, false, true}
+	return UUID(Nil[uuid.UUID]())
 }
 
 // Nil returns whether this scalar is nil
 func (v UUID) Nil() bool {
-	return !v.present
+	return Nillable[uuid.UUID](v).Nil()
+}
+
+// Initialized returns whether this scalar has been set, either to nil or to a
+// non-nil value
+func (v UUID) Initialized() bool {
+	return Nillable[uuid.UUID](v).Initialized()
+}
+
+// Set is a synonym for Initialized
+func (v UUID) Set() bool {
+	return Nillable[uuid.UUID](v).Set()
+}
+
+// State returns whether this scalar is unset, nil or non-nil
+func (v UUID) State() State {
+	return Nillable[uuid.UUID](v).State()
+}
+
+// Reset returns this scalar to the unset state
+func (v *UUID) Reset() {
+	(*Nillable[uuid.UUID])(v).Reset()
 }
 
 // String implements the fmt.Stringer interface
@@ -60,48 +75,55 @@ func (v UUID) UUID() uuid.UUID {
 
 // UnmarshalJSON implements the json.Unmarshaler interface
 func (v *UUID) UnmarshalJSON(data []byte) error {
-	if bytes.Equal(data, []byte{'n', 'u', 'l', 'l'}) {
-		v.present = false
-		v.initialized = true
-		return nil
-	}
-	err := json.Unmarshal(data, &v.v)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	v.present = true
-	v.initialized = true
-	return nil
+	return (*Nillable[uuid.UUID])(v).UnmarshalJSON(data)
 }
 
 // MarshalJSON implements the json.Marshaler interface
 func (v UUID) MarshalJSON() ([]byte, error) {
-	if !v.initialized || !v.present {
-		return []byte{'n', 'u', 'l', 'l'}, nil
-	}
-	return json.Marshal(v.v)
+	return Nillable[uuid.UUID](v).MarshalJSON()
+}
+
+// Value implements the driver.Valuer interface, returning the value as its string form,
+// or as UNIQUEIDENTIFIER bytes under the SQLServer dialect
+func (v UUID) Value() (driver.Value, error) {
+	return v.value(CurrentDialect())
 }
 
-// Value implements the driver.Valuer interface
-func (v UUID) Value() (driver.Value, error) { //nolint:unparam
+func (v UUID) value(d Dialect) (driver.Value, error) { //nolint:unparam
 	if !v.present {
 		return nil, nil
 	}
-	return v.v, nil
+	if d == SQLServer {
+		u := swapUUIDEndianness(v.v)
+		return u[:], nil
+	}
+	return v.v.String(), nil
 }
 
-// Scan implements the sql.Scanner interface
+// Scan implements the sql.Scanner interface. 16 bytes are scanned as a binary
+// UUID, or as UNIQUEIDENTIFIER bytes under the SQLServer dialect; other bytes
+// and strings are parsed as text.
 func (v *UUID) Scan(src any) error {
+	return v.scan(CurrentDialect(), src)
+}
+
+func (v *UUID) scan(d Dialect, src any) error {
 	if src == nil {
 		*v = UUID{present: false, initialized: true}
 		return nil
 	}
 	switch t := src.(type) {
 	case []byte:
+		if len(t) != 16 {
+			return v.scan(d, string(t))
+		}
 		u, err := uuid.FromBytes(t)
 		if err != nil {
 			return err
 		}
+		if d == SQLServer {
+			u = swapUUIDEndianness(u)
+		}
 		*v = UUID{present: true, v: u, initialized: true}
 		return nil
 	case string:
@@ -122,10 +144,19 @@ func (v *UUID) ScanUUID(src pgtype.UUID) error { //nolint:unparam
 }
 
 // UUIDValue implements the pgtype.UUIDValuer interface
-func (v *UUID) UUIDValue() (pgtype.UUID, error) { //nolint:unparam
+func (v UUID) UUIDValue() (pgtype.UUID, error) { //nolint:unparam
 	return pgtype.UUID{Bytes: v.v, Valid: v.present}, nil
 }
 
+// swapUUIDEndianness converts between the big-endian byte order of RFC 4122
+// and the mixed-endian order of SQL Server's UNIQUEIDENTIFIER
+func swapUUIDEndianness(u uuid.UUID) uuid.UUID {
+	u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+	u[4], u[5] = u[5], u[4]
+	u[6], u[7] = u[7], u[6]
+	return u
+}
+
 func StringsToUUIDs(ids []string) []uuid.UUID {
 	v := make([]uuid.UUID, len(ids))
 	for i := range ids {
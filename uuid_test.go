@@ -168,7 +168,7 @@ func TestUUID_Value(t *testing.T) {
 		{
 			name:    "Not Nil",
 			give:    UUID{v: stubUUID, present: true, initialized: true},
-			want:    stubUUID,
+			want:    stubUUIDString,
 			wantErr: false,
 		},
 	}